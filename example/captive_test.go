@@ -0,0 +1,100 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/binodta/depWeaver/pkg/di"
+)
+
+type CaptiveSession struct{}
+type CaptiveFormatter struct{ Session *CaptiveSession }
+type CaptiveCache struct{ Formatter *CaptiveFormatter }
+type CaptiveClock struct{}
+type CaptiveScheduler struct{ Clock *CaptiveClock }
+
+func NewCaptiveSession() *CaptiveSession                             { return &CaptiveSession{} }
+func NewCaptiveFormatter(s *CaptiveSession) *CaptiveFormatter        { return &CaptiveFormatter{Session: s} }
+func NewCaptiveCache(f *CaptiveFormatter) *CaptiveCache              { return &CaptiveCache{Formatter: f} }
+func NewCaptiveClock() *CaptiveClock                                 { return &CaptiveClock{} }
+func NewCaptiveScheduler(c *CaptiveClock) *CaptiveScheduler          { return &CaptiveScheduler{Clock: c} }
+func NewLazyCaptiveCache(di.Provider[*CaptiveSession]) *CaptiveCache { return &CaptiveCache{} }
+
+// TestCaptiveScopedDependency verifies singleton -> scoped edges fail validation with the full path
+func TestCaptiveScopedDependency(t *testing.T) {
+	c := di.New()
+	err := c.InitWithScope([]di.ScopeRegistration{
+		{Constructor: NewCaptiveSession, Scope: di.Scoped},
+		{Constructor: NewCaptiveFormatter, Scope: di.Transient},
+		{Constructor: NewCaptiveCache, Scope: di.Singleton},
+	})
+
+	var captive *di.CaptiveDependencyError
+	if !errors.As(err, &captive) {
+		t.Fatalf("Expected CaptiveDependencyError, got: %v", err)
+	}
+	if captive.Scope != di.Scoped {
+		t.Errorf("Expected scoped capture, got %v", captive.Scope)
+	}
+	want := "*main.CaptiveCache -> *main.CaptiveFormatter -> *main.CaptiveSession"
+	if !strings.Contains(err.Error(), want) {
+		t.Errorf("Expected path %q in error, got: %v", want, err)
+	}
+
+	// The transient in the middle is only a warning by default
+	report := c.ValidationReport()
+	if len(report.Captive) != 1 || len(report.Warnings) != 1 {
+		t.Errorf("Expected 1 captive error and 1 warning, got %d and %d", len(report.Captive), len(report.Warnings))
+	}
+}
+
+// TestCaptiveTransientPolicy verifies singleton -> transient edges follow the configured policy
+func TestCaptiveTransientPolicy(t *testing.T) {
+	c := di.New()
+	if err := c.InitWithScope([]di.ScopeRegistration{
+		{Constructor: NewCaptiveClock, Scope: di.Transient},
+		{Constructor: NewCaptiveScheduler, Scope: di.Singleton},
+	}); err != nil {
+		t.Fatalf("Expected transient capture to be a warning by default: %v", err)
+	}
+	if warnings := c.ValidationReport().Warnings; len(warnings) != 1 || !strings.Contains(warnings[0].Error(), "captures transient *main.CaptiveClock") {
+		t.Errorf("Expected one transient capture warning, got %v", warnings)
+	}
+
+	c.SetTransientCapturePolicy(di.CaptureAllow)
+	if report := c.ValidationReport(); len(report.Warnings) != 0 {
+		t.Errorf("Expected no warnings under CaptureAllow, got %v", report.Warnings)
+	}
+
+	c.SetTransientCapturePolicy(di.CaptureError)
+	var captive *di.CaptiveDependencyError
+	if err := c.Validate(); !errors.As(err, &captive) || captive.Scope != di.Transient {
+		t.Errorf("Expected transient capture error under CaptureError, got: %v", err)
+	}
+}
+
+// TestCaptiveLazyEdge verifies a singleton's provider of a scoped dependency is captive: the provider
+// keeps the scope the singleton was built in, while providers of transients build afresh on every call
+func TestCaptiveLazyEdge(t *testing.T) {
+	c := di.New()
+	err := c.InitWithScope([]di.ScopeRegistration{
+		{Constructor: NewCaptiveSession, Scope: di.Scoped},
+		{Constructor: NewLazyCaptiveCache, Scope: di.Singleton},
+	})
+	var captive *di.CaptiveDependencyError
+	if !errors.As(err, &captive) || captive.Scope != di.Scoped {
+		t.Errorf("Expected a lazy edge to a scoped dependency to be captive, got: %v", err)
+	}
+
+	c = di.New()
+	if err := c.InitWithScope([]di.ScopeRegistration{
+		{Constructor: NewCaptiveSession, Scope: di.Transient},
+		{Constructor: NewLazyCaptiveCache, Scope: di.Singleton},
+	}); err != nil {
+		t.Fatalf("Expected a lazy edge to a transient to be allowed: %v", err)
+	}
+	if report := c.ValidationReport(); len(report.Warnings) != 0 {
+		t.Errorf("Expected no transient warning behind a lazy edge, got %v", report.Warnings)
+	}
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/binodta/depWeaver/pkg/di"
+)
+
+type TenantConfig struct{ Tenant string }
+type TenantRepo struct{ Config *TenantConfig }
+type TenantHandler struct {
+	Config *TenantConfig
+	Repo   *TenantRepo
+}
+type TenantAudit struct{}
+
+func NewTenantRepo(cfg *TenantConfig) *TenantRepo { return &TenantRepo{Config: cfg} }
+func NewTenantHandler(cfg *TenantConfig, repo *TenantRepo) *TenantHandler {
+	return &TenantHandler{Config: cfg, Repo: repo}
+}
+
+type TenantSession struct{ closed *atomic.Int32 }
+
+func (s *TenantSession) Close() error {
+	s.closed.Add(1)
+	return nil
+}
+
+type TenantCache struct{ Session *TenantSession }
+
+func newTenantParent(t *testing.T) *di.Container {
+	t.Helper()
+	parent := di.New()
+	err := parent.Init([]interface{}{
+		func() *TenantConfig { return &TenantConfig{Tenant: "default"} },
+		NewTenantRepo,
+	})
+	if err != nil {
+		t.Fatalf("Failed to init parent: %v", err)
+	}
+	return parent
+}
+
+// TestChildOverridesAndFallsBack verifies child registrations win and unknown types come from the parent
+func TestChildOverridesAndFallsBack(t *testing.T) {
+	parent := newTenantParent(t)
+	child := parent.Child()
+	err := child.Init([]interface{}{
+		func() *TenantConfig { return &TenantConfig{Tenant: "acme"} },
+		NewTenantHandler,
+	})
+	if err != nil {
+		t.Fatalf("Failed to init child: %v", err)
+	}
+
+	handler, err := di.ResolveFrom[*TenantHandler](child)
+	if err != nil {
+		t.Fatalf("Failed to resolve: %v", err)
+	}
+	if handler.Config.Tenant != "acme" {
+		t.Errorf("Expected the child's override, got %q", handler.Config.Tenant)
+	}
+
+	// The repo is a parent singleton: built by the parent, with the parent's config
+	parentRepo, _ := di.ResolveFrom[*TenantRepo](parent)
+	if handler.Repo != parentRepo {
+		t.Error("Expected the parent's repo singleton to be shared with the child")
+	}
+	if handler.Repo.Config.Tenant != "default" {
+		t.Errorf("Expected the parent singleton to use the parent's config, got %q", handler.Repo.Config.Tenant)
+	}
+
+	// The parent never sees child registrations
+	if _, err := di.ResolveFrom[*TenantHandler](parent); err == nil {
+		t.Error("Expected the parent not to resolve child registrations")
+	}
+}
+
+// TestChildValidationUnion verifies child validation covers both graphs
+func TestChildValidationUnion(t *testing.T) {
+	parent := newTenantParent(t)
+	child := parent.Child()
+
+	if err := child.RegisterRuntime(NewTenantHandler, di.Singleton); err != nil {
+		t.Fatalf("Expected dependencies registered in the parent to satisfy the child: %v", err)
+	}
+
+	err := child.RegisterRuntime(func(*TenantAudit) *TenantCache { return &TenantCache{} }, di.Singleton)
+	var missing *di.MissingDependencyError
+	if !errors.As(err, &missing) || missing.Type.String() != "*main.TenantAudit" {
+		t.Fatalf("Expected a missing *main.TenantAudit, got %v", err)
+	}
+
+	// Problems in the parent break the child too
+	broken := di.New()
+	_ = broken.RegisterRuntime(func(*TenantAudit) *TenantRepo { return &TenantRepo{} }, di.Singleton)
+	if err := broken.Child().Validate(); err == nil || !strings.Contains(err.Error(), "*main.TenantAudit") {
+		t.Errorf("Expected the parent's missing dependency in the child's report, got %v", err)
+	}
+}
+
+// TestChildCaptiveAcrossContainers verifies a child singleton capturing a parent scoped registration is reported
+func TestChildCaptiveAcrossContainers(t *testing.T) {
+	var closed atomic.Int32
+	parent := di.New()
+	if err := parent.RegisterRuntime(func() *TenantSession { return &TenantSession{closed: &closed} }, di.Scoped); err != nil {
+		t.Fatalf("Failed to register: %v", err)
+	}
+	child := parent.Child()
+
+	err := child.RegisterRuntime(func(s *TenantSession) *TenantCache { return &TenantCache{Session: s} }, di.Singleton)
+	var captive *di.CaptiveDependencyError
+	if !errors.As(err, &captive) {
+		t.Fatalf("Expected a captive dependency error, got %v", err)
+	}
+}
+
+// TestChildScopesAndGroups verifies scopes and value groups span the hierarchy
+func TestChildScopesAndGroups(t *testing.T) {
+	var closed atomic.Int32
+	parent := di.New()
+	if err := parent.RegisterRuntime(func() *TenantSession { return &TenantSession{closed: &closed} }, di.Scoped); err != nil {
+		t.Fatalf("Failed to register: %v", err)
+	}
+	if err := di.ProvideToGroup(parent, "health", NewCacheHealth); err != nil {
+		t.Fatalf("Failed to add group member: %v", err)
+	}
+	child := parent.Child()
+	if err := di.ProvideToGroup(child, "health", NewQueueHealth); err != nil {
+		t.Fatalf("Failed to add group member: %v", err)
+	}
+
+	// Child groups contain the parent's members first
+	checkers, err := di.ResolveGroupFrom[HealthChecker](child, "health")
+	if err != nil {
+		t.Fatalf("Failed to resolve group: %v", err)
+	}
+	if names := healthNames(checkers); names != "cache,queue" {
+		t.Errorf("Expected cache,queue, got %s", names)
+	}
+	parentCheckers, _ := di.ResolveGroupFrom[HealthChecker](parent, "health")
+	if names := healthNames(parentCheckers); names != "cache" {
+		t.Errorf("Expected the parent group to be unchanged, got %s", names)
+	}
+
+	// Destroying a child scope closes the instances the parent built for it
+	scopeID := child.CreateScope()
+	s1, err := di.ResolveScopedFrom[*TenantSession](child, scopeID)
+	if err != nil {
+		t.Fatalf("Failed to resolve scoped: %v", err)
+	}
+	s2, _ := di.ResolveScopedFrom[*TenantSession](child, scopeID)
+	if s1 != s2 {
+		t.Error("Expected one instance per scope")
+	}
+	if err := child.DestroyScope(scopeID); err != nil {
+		t.Fatalf("Failed to destroy scope: %v", err)
+	}
+	if closed.Load() != 1 {
+		t.Errorf("Expected the scoped instance to be closed, got %d closes", closed.Load())
+	}
+}
+
+// TestParentDestroyScopeClosesChildInstances verifies destroying a scope in the parent reaches its children
+func TestParentDestroyScopeClosesChildInstances(t *testing.T) {
+	var closed atomic.Int32
+	parent := di.New()
+	child := parent.Child()
+	if err := child.RegisterRuntime(func() *TenantSession { return &TenantSession{closed: &closed} }, di.Scoped); err != nil {
+		t.Fatalf("Failed to register: %v", err)
+	}
+
+	scopeID := parent.CreateScope()
+	s1, err := di.ResolveScopedFrom[*TenantSession](child, scopeID)
+	if err != nil {
+		t.Fatalf("Failed to resolve scoped: %v", err)
+	}
+	if err := parent.DestroyScope(scopeID); err != nil {
+		t.Fatalf("Failed to destroy scope: %v", err)
+	}
+	if closed.Load() != 1 {
+		t.Errorf("Expected the child's scoped instance to be closed, got %d closes", closed.Load())
+	}
+	if s2, _ := di.ResolveScopedFrom[*TenantSession](child, scopeID); s1 == s2 {
+		t.Error("Expected the child's cache for the scope to be cleared")
+	}
+}
+
+type numberedHealth struct{ n string }
+
+func (h *numberedHealth) Name() string { return h.n }
+
+// TestChildGroupAfterParentAddsMember verifies cached child members keep their identity when the parent's group grows
+func TestChildGroupAfterParentAddsMember(t *testing.T) {
+	parent := di.New()
+	if err := di.ProvideToGroup(parent, "health", NewCacheHealth); err != nil {
+		t.Fatalf("Failed to add group member: %v", err)
+	}
+	child := parent.Child()
+	for _, ctor := range []interface{}{
+		func() *numberedHealth { return &numberedHealth{n: "first"} },
+		func() *numberedHealth { return &numberedHealth{n: "second"} },
+	} {
+		if err := di.ProvideToGroup(child, "health", ctor); err != nil {
+			t.Fatalf("Failed to add group member: %v", err)
+		}
+	}
+
+	before, err := di.ResolveGroupFrom[HealthChecker](child, "health")
+	if err != nil {
+		t.Fatalf("Failed to resolve group: %v", err)
+	}
+	if names := healthNames(before); names != "cache,first,second" {
+		t.Fatalf("Expected cache,first,second, got %s", names)
+	}
+
+	if err := di.ProvideToGroup(parent, "health", NewQueueHealth); err != nil {
+		t.Fatalf("Failed to add group member: %v", err)
+	}
+	after, err := di.ResolveGroupFrom[HealthChecker](child, "health")
+	if err != nil {
+		t.Fatalf("Failed to resolve group: %v", err)
+	}
+	if names := healthNames(after); names != "cache,queue,first,second" {
+		t.Errorf("Expected cache,queue,first,second, got %s", names)
+	}
+	if len(after) == 4 && (after[2] != before[1] || after[3] != before[2]) {
+		t.Error("Expected the child's singleton members to keep their cached instances")
+	}
+}
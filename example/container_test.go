@@ -0,0 +1,104 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/binodta/depWeaver/internal/container"
+	"github.com/binodta/depWeaver/pkg/di"
+)
+
+type AdminServer struct {
+	Config *Config
+}
+
+func NewAdminServer(cfg *Config) *AdminServer {
+	return &AdminServer{Config: cfg}
+}
+
+// TestIndependentContainers verifies that two containers hold separate graphs
+func TestIndependentContainers(t *testing.T) {
+	t.Parallel()
+
+	admin := di.New()
+	public := di.New()
+
+	if err := admin.Init([]interface{}{NewConfig, NewAdminServer}); err != nil {
+		t.Fatalf("Failed to init admin container: %v", err)
+	}
+	if err := di.ProvideTo(public, NewConfig); err != nil {
+		t.Fatalf("Failed to provide to public container: %v", err)
+	}
+
+	adminCfg, err := di.ResolveFrom[*Config](admin)
+	if err != nil {
+		t.Fatalf("Failed to resolve Config from admin: %v", err)
+	}
+	publicCfg, err := di.ResolveFrom[*Config](public)
+	if err != nil {
+		t.Fatalf("Failed to resolve Config from public: %v", err)
+	}
+
+	// Each container owns its own singleton cache
+	if adminCfg == publicCfg {
+		t.Error("Expected different Config instances across containers")
+	}
+
+	// Registrations do not leak between containers
+	if _, err := di.ResolveFrom[*AdminServer](public); err == nil {
+		t.Error("Expected AdminServer to be unresolvable from public container")
+	}
+
+	server, err := di.ResolveFrom[*AdminServer](admin)
+	if err != nil {
+		t.Fatalf("Failed to resolve AdminServer: %v", err)
+	}
+	if server.Config != adminCfg {
+		t.Error("Expected AdminServer to receive the admin container's Config")
+	}
+}
+
+// TestContainerScopes verifies scope management on an explicit container
+func TestContainerScopes(t *testing.T) {
+	t.Parallel()
+
+	c := di.New()
+	if err := c.InitWithScope([]di.ScopeRegistration{
+		{Constructor: NewRequestContext, Scope: container.Scoped},
+	}); err != nil {
+		t.Fatalf("Failed to init container: %v", err)
+	}
+
+	scopeID := c.CreateScope()
+	defer c.DestroyScope(scopeID)
+
+	ctx1, err := di.ResolveScopedFrom[*RequestContext](c, scopeID)
+	if err != nil {
+		t.Fatalf("Failed to resolve RequestContext: %v", err)
+	}
+	ctx2, _ := di.ResolveScopedFrom[*RequestContext](c, scopeID)
+	if ctx1 != ctx2 {
+		t.Error("Expected same instance within the same scope")
+	}
+
+	// The default container is untouched
+	if _, err := di.ResolveFrom[*RequestContext](di.New()); err == nil {
+		t.Error("Expected fresh container to have no registrations")
+	}
+}
+
+// TestProvideToAnyOrder verifies ProvideTo accepts a constructor before its dependencies
+func TestProvideToAnyOrder(t *testing.T) {
+	c := di.New()
+	if err := di.ProvideTo(c, NewAdminServer); err != nil {
+		t.Fatalf("Expected ProvideTo not to validate the incomplete graph: %v", err)
+	}
+	if err := c.Validate(); err == nil {
+		t.Error("Expected Validate to report the missing *Config")
+	}
+	if err := di.ProvideTo(c, NewConfig); err != nil {
+		t.Fatalf("Failed to provide: %v", err)
+	}
+	if err := c.Validate(); err != nil {
+		t.Errorf("Expected the completed graph to be valid: %v", err)
+	}
+}
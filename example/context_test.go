@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/binodta/depWeaver/pkg/di"
+)
+
+type ctxKey struct{}
+
+type CtxRemoteConfig struct{ Source string }
+type CtxClient struct{ Config *CtxRemoteConfig }
+
+func NewCtxRemoteConfig(ctx context.Context) (*CtxRemoteConfig, error) {
+	source, _ := ctx.Value(ctxKey{}).(string)
+	return &CtxRemoteConfig{Source: source}, nil
+}
+
+func NewCtxClient(cfg *CtxRemoteConfig) *CtxClient { return &CtxClient{Config: cfg} }
+
+type CtxClientParams struct {
+	di.In
+	Ctx    context.Context
+	Config *CtxRemoteConfig
+}
+
+type CtxParamClient struct {
+	Source string
+	Config *CtxRemoteConfig
+}
+
+func NewCtxParamClient(p CtxClientParams) *CtxParamClient {
+	source, _ := p.Ctx.Value(ctxKey{}).(string)
+	return &CtxParamClient{Source: source, Config: p.Config}
+}
+
+type CtxSlow struct{}
+type CtxSlowConsumer struct{}
+
+// TestResolveCtxInjectsContext verifies constructors receive the resolution context
+func TestResolveCtxInjectsContext(t *testing.T) {
+	c := di.New()
+	if err := c.Init([]interface{}{NewCtxRemoteConfig, NewCtxClient}); err != nil {
+		t.Fatalf("context.Context parameters should not need registration: %v", err)
+	}
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, "etcd")
+	client, err := di.ResolveCtxFrom[*CtxClient](ctx, c)
+	if err != nil {
+		t.Fatalf("Failed to resolve: %v", err)
+	}
+	if client.Config.Source != "etcd" {
+		t.Errorf("Expected context to reach the constructor, got %q", client.Config.Source)
+	}
+}
+
+// TestResolveCtxCanceled verifies a done context stops resolution with the path in the error
+func TestResolveCtxCanceled(t *testing.T) {
+	c := di.New()
+	if err := c.Init([]interface{}{NewCtxRemoteConfig, NewCtxClient}); err != nil {
+		t.Fatalf("Failed to init: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := di.ResolveCtxFrom[*CtxClient](ctx, c)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "*main.CtxClient") {
+		t.Errorf("Expected resolution path in error, got: %v", err)
+	}
+}
+
+// TestResolveCtxAbortsWait verifies waiting on another goroutine's construction honors the deadline
+func TestResolveCtxAbortsWait(t *testing.T) {
+	c := di.New()
+	release := make(chan struct{})
+	started := make(chan struct{})
+	if err := c.Init([]interface{}{
+		func() *CtxSlow {
+			close(started)
+			<-release
+			return &CtxSlow{}
+		},
+		func(*CtxSlow) *CtxSlowConsumer { return &CtxSlowConsumer{} },
+	}); err != nil {
+		t.Fatalf("Failed to init: %v", err)
+	}
+	defer close(release)
+
+	go di.ResolveFrom[*CtxSlow](c)
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	var err error
+	runWithTimeout(t, 2*time.Second, func() {
+		_, err = di.ResolveCtxFrom[*CtxSlowConsumer](ctx, c)
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected deadline exceeded while waiting, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "*main.CtxSlowConsumer -> *main.CtxSlow") {
+		t.Errorf("Expected resolution path in error, got: %v", err)
+	}
+}
+
+// TestResolveNamedCtx verifies named resolution passes the context and honors cancellation
+func TestResolveNamedCtx(t *testing.T) {
+	c := di.New()
+	if err := c.RegisterNamedConstructor("remote", NewCtxRemoteConfig, di.Scoped); err != nil {
+		t.Fatalf("Failed to register: %v", err)
+	}
+	scopeID := c.CreateScope()
+	defer c.DestroyScope(scopeID)
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, "consul")
+	cfg, err := di.ResolveNamedScopedCtxFrom[*CtxRemoteConfig](ctx, c, "remote", scopeID)
+	if err != nil {
+		t.Fatalf("Failed to resolve: %v", err)
+	}
+	if cfg.Source != "consul" {
+		t.Errorf("Expected context to reach the named constructor, got %q", cfg.Source)
+	}
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	other := c.CreateScope()
+	defer c.DestroyScope(other)
+	if _, err := di.ResolveNamedScopedCtxFrom[*CtxRemoteConfig](canceled, c, "remote", other); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got: %v", err)
+	}
+}
+
+// TestResolveCtxInParamObject verifies a context.Context field of a di.In object receives the resolution context
+func TestResolveCtxInParamObject(t *testing.T) {
+	c := di.New()
+	if err := c.Init([]interface{}{NewCtxRemoteConfig, NewCtxParamClient}); err != nil {
+		t.Fatalf("context.Context fields should not need registration: %v", err)
+	}
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, "vault")
+	client, err := di.ResolveCtxFrom[*CtxParamClient](ctx, c)
+	if err != nil {
+		t.Fatalf("Failed to resolve: %v", err)
+	}
+	if client.Source != "vault" || client.Config.Source != "vault" {
+		t.Errorf("Expected context to reach the parameter object, got %q and %q", client.Source, client.Config.Source)
+	}
+}
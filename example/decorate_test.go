@@ -0,0 +1,179 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/binodta/depWeaver/pkg/di"
+)
+
+type DecoRepository interface {
+	Find(id string) string
+}
+
+type decoDBRepository struct{ gen int }
+
+func (decoDBRepository) Find(id string) string { return "db:" + id }
+
+func NewDecoDBRepository() *decoDBRepository { return &decoDBRepository{} }
+
+type DecoLogger struct{ Lines []string }
+
+func NewDecoLogger() *DecoLogger { return &DecoLogger{} }
+
+type loggingRepository struct {
+	next DecoRepository
+	log  *DecoLogger
+}
+
+func (r *loggingRepository) Find(id string) string {
+	r.log.Lines = append(r.log.Lines, "find "+id)
+	return r.next.Find(id)
+}
+
+type cachingRepository struct{ next DecoRepository }
+
+func (r *cachingRepository) Find(id string) string { return "cached(" + r.next.Find(id) + ")" }
+
+var decoratorCalls int
+
+func withLogging(r DecoRepository, log *DecoLogger) DecoRepository {
+	decoratorCalls++
+	return &loggingRepository{next: r, log: log}
+}
+
+func withCaching(r DecoRepository) DecoRepository {
+	return &cachingRepository{next: r}
+}
+
+func newDecoContainer(t *testing.T, scope di.Scope) *di.Container {
+	t.Helper()
+	c := di.New()
+	if err := c.InitWithScope([]di.ScopeRegistration{
+		{Constructor: NewDecoDBRepository, Scope: scope},
+		{Constructor: NewDecoLogger, Scope: di.Singleton},
+	}); err != nil {
+		t.Fatalf("Failed to init: %v", err)
+	}
+	if err := di.BindInterfaceTo[DecoRepository, *decoDBRepository](c); err != nil {
+		t.Fatalf("Failed to bind: %v", err)
+	}
+	return c
+}
+
+// TestDecorateChain verifies decorators wrap in registration order and resolve their own dependencies
+func TestDecorateChain(t *testing.T) {
+	decoratorCalls = 0
+	c := newDecoContainer(t, di.Singleton)
+	if err := c.Decorate(withLogging); err != nil {
+		t.Fatalf("Failed to decorate: %v", err)
+	}
+	if err := c.Decorate(withCaching); err != nil {
+		t.Fatalf("Failed to decorate: %v", err)
+	}
+
+	repo, err := di.ResolveFrom[DecoRepository](c)
+	if err != nil {
+		t.Fatalf("Failed to resolve: %v", err)
+	}
+	if got := repo.Find("42"); got != "cached(db:42)" {
+		t.Errorf("Expected caching outside logging, got %s", got)
+	}
+	logger, _ := di.ResolveFrom[*DecoLogger](c)
+	if len(logger.Lines) != 1 || logger.Lines[0] != "find 42" {
+		t.Errorf("Expected logging decorator to run, got %v", logger.Lines)
+	}
+
+	again, _ := di.ResolveFrom[DecoRepository](c)
+	if again != repo || decoratorCalls != 1 {
+		t.Errorf("Expected decorated singleton to be cached, decorator ran %d times", decoratorCalls)
+	}
+}
+
+// TestDecorateScoped verifies decorated instances follow the decorated type's scope
+func TestDecorateScoped(t *testing.T) {
+	c := newDecoContainer(t, di.Scoped)
+	if err := c.Decorate(withCaching); err != nil {
+		t.Fatalf("Failed to decorate: %v", err)
+	}
+
+	scope1, scope2 := c.CreateScope(), c.CreateScope()
+	defer c.DestroyAllScopes()
+
+	a, err := di.ResolveScopedFrom[DecoRepository](c, scope1)
+	if err != nil {
+		t.Fatalf("Failed to resolve: %v", err)
+	}
+	b, _ := di.ResolveScopedFrom[DecoRepository](c, scope1)
+	other, _ := di.ResolveScopedFrom[DecoRepository](c, scope2)
+	if a != b {
+		t.Error("Expected one decorated instance per scope")
+	}
+	if a == other {
+		t.Error("Expected different decorated instances across scopes")
+	}
+}
+
+// TestDecorateConcrete verifies decorating a concrete type affects its consumers
+func TestDecorateConcrete(t *testing.T) {
+	c := di.New()
+	if err := c.Init([]interface{}{NewConfig, NewDatabaseConnection}); err != nil {
+		t.Fatalf("Failed to init: %v", err)
+	}
+	if err := c.Decorate(func(cfg *Config) *Config {
+		return &Config{DatabaseURL: cfg.DatabaseURL + "?sslmode=require", MaxConnections: cfg.MaxConnections}
+	}); err != nil {
+		t.Fatalf("Failed to decorate: %v", err)
+	}
+
+	db, err := di.ResolveFrom[*DatabaseConnection](c)
+	if err != nil {
+		t.Fatalf("Failed to resolve: %v", err)
+	}
+	if !strings.HasSuffix(db.Url, "?sslmode=require") {
+		t.Errorf("Expected decorated config to be injected, got %s", db.Url)
+	}
+}
+
+// TestDecorateValidation verifies decorator dependencies and signatures are checked
+func TestDecorateValidation(t *testing.T) {
+	c := di.New()
+	if err := c.Init([]interface{}{NewDecoDBRepository}); err != nil {
+		t.Fatalf("Failed to init: %v", err)
+	}
+	if err := di.BindInterfaceTo[DecoRepository, *decoDBRepository](c); err != nil {
+		t.Fatalf("Failed to bind: %v", err)
+	}
+
+	// *DecoLogger is not registered yet; decorating does not validate the graph
+	if err := c.Decorate(withLogging); err != nil {
+		t.Fatalf("Failed to decorate: %v", err)
+	}
+	err := c.Validate()
+	if err == nil || !strings.Contains(err.Error(), "DecoLogger") {
+		t.Errorf("Expected missing decorator dependency error, got: %v", err)
+	}
+
+	if err := di.New().Decorate(func(r DecoRepository) *DecoLogger { return nil }); err == nil {
+		t.Error("Expected error for decorator that changes the type")
+	}
+}
+
+// TestDecorateOverride verifies overriding the bound type also drops the cached decorated interface
+func TestDecorateOverride(t *testing.T) {
+	c := newDecoContainer(t, di.Singleton)
+	if err := c.Decorate(withCaching); err != nil {
+		t.Fatalf("Failed to decorate: %v", err)
+	}
+	if _, err := di.ResolveFrom[DecoRepository](c); err != nil {
+		t.Fatalf("Failed to resolve: %v", err)
+	}
+
+	if err := c.Override(func() *decoDBRepository { return &decoDBRepository{gen: 2} }, di.Singleton); err != nil {
+		t.Fatalf("Failed to override: %v", err)
+	}
+	repo, _ := di.ResolveFrom[DecoRepository](c)
+	if caching, ok := repo.(*cachingRepository); !ok || caching.next.(*decoDBRepository).gen != 2 {
+		t.Error("Expected the decorated interface to wrap the overriding instance")
+	}
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/binodta/depWeaver/pkg/di"
+	"github.com/binodta/depWeaver/pkg/di/dihttp"
+)
+
+type HTTPRequestLog struct {
+	Path   string
+	closed *atomic.Int32
+}
+
+func (l *HTTPRequestLog) Close() error {
+	l.closed.Add(1)
+	return nil
+}
+
+type HTTPGreeter struct {
+	Log *HTTPRequestLog
+	W   http.ResponseWriter
+}
+
+func (g *HTTPGreeter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	fmt.Fprintf(g.W, "hello from %s", g.Log.Path)
+}
+
+func newHTTPContainer(t *testing.T, closed *atomic.Int32) *di.Container {
+	t.Helper()
+	c := di.New()
+	if err := dihttp.Register(c); err != nil {
+		t.Fatalf("Failed to register: %v", err)
+	}
+	err := c.RegisterRuntimeWithScopes([]di.ScopeRegistration{
+		{Constructor: func(r *http.Request) *HTTPRequestLog {
+			return &HTTPRequestLog{Path: r.URL.Path, closed: closed}
+		}, Scope: di.Scoped},
+		{Constructor: func(log *HTTPRequestLog, w http.ResponseWriter) *HTTPGreeter {
+			return &HTTPGreeter{Log: log, W: w}
+		}, Scope: di.Scoped},
+	})
+	if err != nil {
+		t.Fatalf("Failed to register: %v", err)
+	}
+	return c
+}
+
+// TestMiddlewareScopesRequests verifies each request gets its own scope with the request injected
+func TestMiddlewareScopesRequests(t *testing.T) {
+	var closed atomic.Int32
+	c := newHTTPContainer(t, &closed)
+
+	mux := http.NewServeMux()
+	mux.Handle("/", dihttp.Handler[*HTTPGreeter](nil))
+	server := httptest.NewServer(dihttp.Middleware(c)(mux))
+	defer server.Close()
+
+	for i, path := range []string{"/a", "/b"} {
+		resp, err := http.Get(server.URL + path)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatalf("Failed to read response: %v", err)
+		}
+		if string(body) != "hello from "+path {
+			t.Errorf("Expected greeting for %s, got %q", path, body)
+		}
+		if closed.Load() != int32(i+1) {
+			t.Errorf("Expected the request scope to be destroyed after %s, got %d closes", path, closed.Load())
+		}
+	}
+}
+
+// TestResolveOutsideMiddleware verifies handlers fail cleanly without a request scope
+func TestResolveOutsideMiddleware(t *testing.T) {
+	var closed atomic.Int32
+	newHTTPContainer(t, &closed)
+
+	var resolveErr error
+	handler := dihttp.Handler[*HTTPGreeter](func(w http.ResponseWriter, r *http.Request, err error) {
+		resolveErr = err
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusServiceUnavailable || resolveErr == nil {
+		t.Errorf("Expected the error handler to run, got status %d and error %v", rec.Code, resolveErr)
+	}
+}
+
+// TestMiddlewareResolve verifies dihttp.Resolve returns request-scoped instances
+func TestMiddlewareResolve(t *testing.T) {
+	var closed atomic.Int32
+	c := newHTTPContainer(t, &closed)
+
+	var same bool
+	handler := dihttp.Middleware(c)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		first, err := dihttp.Resolve[*HTTPRequestLog](r)
+		if err != nil {
+			t.Errorf("Failed to resolve: %v", err)
+			return
+		}
+		second, _ := dihttp.Resolve[*HTTPRequestLog](r)
+		same = first == second
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/orders", nil))
+	if !same {
+		t.Error("Expected one instance per request")
+	}
+	if closed.Load() != 1 {
+		t.Errorf("Expected the scoped instance to be closed, got %d closes", closed.Load())
+	}
+}
+
+// TestRegisterKeepsExistingRequestConstructor verifies dihttp does not replace a registration of the request type
+func TestRegisterKeepsExistingRequestConstructor(t *testing.T) {
+	c := di.New()
+	err := c.RegisterRuntime(func() *http.Request {
+		return httptest.NewRequest(http.MethodGet, "/own", nil)
+	}, di.Scoped)
+	if err != nil {
+		t.Fatalf("Failed to register: %v", err)
+	}
+
+	var dup *di.DuplicateRegistrationError
+	if err := dihttp.Register(c); !errors.As(err, &dup) {
+		t.Errorf("Expected a duplicate registration error, got %v", err)
+	}
+
+	scopeID := c.CreateScope()
+	defer c.DestroyScope(scopeID)
+	r, err := di.ResolveScopedFrom[*http.Request](c, scopeID)
+	if err != nil || r.URL.Path != "/own" {
+		t.Errorf("Expected the existing constructor to be kept, got %v", err)
+	}
+}
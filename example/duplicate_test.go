@@ -0,0 +1,107 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/binodta/depWeaver/pkg/di"
+)
+
+type DupClient struct{ Team string }
+
+func NewPaymentsClient() *DupClient { return &DupClient{Team: "payments"} }
+func NewSearchClient() *DupClient   { return &DupClient{Team: "search"} }
+
+// TestDuplicateRegistration verifies a second constructor for a type is rejected with both locations
+func TestDuplicateRegistration(t *testing.T) {
+	c := di.New()
+	err := c.Init([]interface{}{NewPaymentsClient, NewSearchClient})
+
+	var dup *di.DuplicateRegistrationError
+	if !errors.As(err, &dup) {
+		t.Fatalf("Expected a duplicate registration error, got %v", err)
+	}
+	if !strings.Contains(dup.Existing, "example.NewPaymentsClient (duplicate_test.go:") ||
+		!strings.Contains(dup.Duplicate, "example.NewSearchClient (duplicate_test.go:") {
+		t.Errorf("Expected both constructors with their locations, got %q and %q", dup.Existing, dup.Duplicate)
+	}
+
+	client, _ := di.ResolveFrom[*DupClient](c)
+	if client.Team != "payments" {
+		t.Errorf("Expected the first registration to stay in place, got %s", client.Team)
+	}
+
+	// Registering the same constructor again is harmless
+	if err := c.RegisterRuntime(NewPaymentsClient, di.Singleton); err != nil {
+		t.Errorf("Expected re-registering the same constructor to succeed: %v", err)
+	}
+	// ...but not with a different lifetime
+	if err := c.RegisterRuntime(NewPaymentsClient, di.Transient); !errors.As(err, &dup) {
+		t.Errorf("Expected a duplicate error for a different lifetime, got %v", err)
+	}
+	// Closures from one literal share a name and position but not their captured values
+	c2 := di.New()
+	for _, team := range []string{"payments", "search"} {
+		err = di.ProvideTo(c2, func() *DupClient { return &DupClient{Team: team} })
+	}
+	if !errors.As(err, &dup) {
+		t.Errorf("Expected a duplicate error for a second closure, got %v", err)
+	}
+	// Nor with different parameter qualifiers or from a module: the options would be lost
+	if err := di.ProvideTo(c, NewPaymentsClient, di.WithParamNames()); err != nil {
+		t.Errorf("Expected identical options to be accepted, got %v", err)
+	}
+	if err := c.Install(di.Module("payments", di.Provide(NewPaymentsClient))); !errors.As(err, &dup) {
+		t.Errorf("Expected a duplicate error for the same constructor from a module, got %v", err)
+	}
+	// Supplied values cannot silently take over either
+	if err := c.Supply(&DupClient{Team: "value"}); !errors.As(err, &dup) {
+		t.Errorf("Expected a duplicate error for a supplied value, got %v", err)
+	}
+}
+
+// TestDuplicateReplacement verifies Override and the Replace option are the ways to replace
+func TestDuplicateReplacement(t *testing.T) {
+	c := di.New()
+	if err := c.Init([]interface{}{NewPaymentsClient}); err != nil {
+		t.Fatalf("Failed to init: %v", err)
+	}
+
+	if err := c.Override(NewSearchClient, di.Singleton); err != nil {
+		t.Fatalf("Failed to override: %v", err)
+	}
+	if client, _ := di.ResolveFrom[*DupClient](c); client.Team != "search" {
+		t.Errorf("Expected the override, got %s", client.Team)
+	}
+
+	if err := di.ProvideTo(c, NewPaymentsClient, di.Replace()); err != nil {
+		t.Fatalf("Failed to replace: %v", err)
+	}
+
+	if err := di.ProvideTo(c, NewSearchClient, di.WithName("search")); err != nil {
+		t.Fatalf("Failed to register named: %v", err)
+	}
+	if err := di.ProvideTo(c, NewPaymentsClient, di.WithName("search")); err == nil {
+		t.Error("Expected a duplicate error for a named registration")
+	}
+	if err := c.OverrideNamed("search", NewPaymentsClient, di.Singleton); err != nil {
+		t.Errorf("Failed to override named: %v", err)
+	}
+}
+
+// TestDuplicateAcrossModules verifies duplicate diagnostics name the modules involved
+func TestDuplicateAcrossModules(t *testing.T) {
+	payments := di.Module("payments", di.Provide(NewPaymentsClient))
+	search := di.Module("search", di.Provide(NewSearchClient))
+
+	err := di.New().Install(payments, search)
+	if err == nil {
+		t.Fatal("Expected a duplicate registration error")
+	}
+	for _, want := range []string{"module search:", "in module payments", "example.NewSearchClient"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected %q in %v", want, err)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/binodta/depWeaver/pkg/di"
+)
+
+type FlakyConn struct{}
+type FlakyClient struct{ Conn *FlakyConn }
+
+var errFlakyDial = errors.New("dial failed")
+
+func NewFlakyClient(c *FlakyConn) *FlakyClient { return &FlakyClient{Conn: c} }
+
+// TestFailureSharedWithWaiters verifies callers waiting on a failing build get its error instead of rebuilding
+func TestFailureSharedWithWaiters(t *testing.T) {
+	var calls atomic.Int32
+	entered := make(chan struct{})
+	release := make(chan struct{})
+
+	c := di.New()
+	err := c.Init([]interface{}{func() (*FlakyConn, error) {
+		if calls.Add(1) == 1 {
+			close(entered)
+			<-release
+		}
+		return nil, errFlakyDial
+	}})
+	if err != nil {
+		t.Fatalf("Failed to init: %v", err)
+	}
+
+	errs := make(chan error, 10)
+	go func() {
+		_, err := di.ResolveFrom[*FlakyConn](c)
+		errs <- err
+	}()
+	<-entered
+
+	var wg sync.WaitGroup
+	for i := 0; i < 9; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := di.ResolveFrom[*FlakyConn](c)
+			errs <- err
+		}()
+	}
+	// Let the waiters queue up behind the builder
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	for i := 0; i < 10; i++ {
+		if err := <-errs; !errors.Is(err, errFlakyDial) {
+			t.Errorf("Expected the builder's error, got %v", err)
+		}
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("Expected one build for all concurrent callers, got %d", n)
+	}
+
+	// By default the next call builds again
+	_, _ = di.ResolveFrom[*FlakyConn](c)
+	if n := calls.Load(); n != 2 {
+		t.Errorf("Expected a new build on the next call, got %d builds", n)
+	}
+}
+
+// TestFailureCached verifies CacheFailure keeps returning the error until the registration changes
+func TestFailureCached(t *testing.T) {
+	var calls atomic.Int32
+	c := di.New()
+	c.SetFailurePolicy(di.FailurePolicy{Mode: di.CacheFailure})
+	err := c.Init([]interface{}{
+		func() (*FlakyConn, error) { calls.Add(1); return nil, errFlakyDial },
+		NewFlakyClient,
+	})
+	if err != nil {
+		t.Fatalf("Failed to init: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := di.ResolveFrom[*FlakyClient](c); !errors.Is(err, errFlakyDial) {
+			t.Errorf("Expected the cached error, got %v", err)
+		}
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("Expected the failure to be cached after one build, got %d", n)
+	}
+
+	// Overriding the broken constructor forgets the failures of it and its consumers
+	if err := c.Override(func() *FlakyConn { return &FlakyConn{} }, di.Singleton); err != nil {
+		t.Fatalf("Failed to override: %v", err)
+	}
+	if _, err := di.ResolveFrom[*FlakyClient](c); err != nil {
+		t.Errorf("Expected the override to clear cached failures, got %v", err)
+	}
+}
+
+// TestFailureRetryWithBackoff verifies the failing constructor is retried and the waiters keep their scope
+func TestFailureRetryWithBackoff(t *testing.T) {
+	var connCalls, clientCalls atomic.Int32
+	c := di.New()
+	c.SetFailurePolicy(di.Backoff(3, time.Millisecond))
+	err := c.InitWithScope([]di.ScopeRegistration{
+		{Constructor: func() (*FlakyConn, error) {
+			if connCalls.Add(1) < 3 {
+				return nil, errFlakyDial
+			}
+			return &FlakyConn{}, nil
+		}, Scope: di.Scoped},
+		{Constructor: func(conn *FlakyConn) *FlakyClient {
+			clientCalls.Add(1)
+			return &FlakyClient{Conn: conn}
+		}, Scope: di.Scoped},
+	})
+	if err != nil {
+		t.Fatalf("Failed to init: %v", err)
+	}
+
+	scopeID := c.CreateScope()
+	defer c.DestroyScope(scopeID)
+	client, err := di.ResolveScopedFrom[*FlakyClient](c, scopeID)
+	if err != nil || client.Conn == nil {
+		t.Fatalf("Expected the third attempt to succeed, got %v", err)
+	}
+	if connCalls.Load() != 3 || clientCalls.Load() != 1 {
+		t.Errorf("Expected only the failing constructor to be retried, got %d conn and %d client builds", connCalls.Load(), clientCalls.Load())
+	}
+
+	// Out of attempts: the last error is returned
+	c.SetFailurePolicy(di.Backoff(2, time.Millisecond))
+	connCalls.Store(0)
+	other := c.CreateScope()
+	defer c.DestroyScope(other)
+	if _, err := di.ResolveScopedFrom[*FlakyConn](c, other); !errors.Is(err, errFlakyDial) {
+		t.Errorf("Expected the error after two attempts, got %v", err)
+	}
+	if n := connCalls.Load(); n != 2 {
+		t.Errorf("Expected two attempts, got %d", n)
+	}
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/binodta/depWeaver/pkg/di"
+)
+
+type GraphStore interface {
+	Get(key string) string
+}
+
+type graphMemStore struct{}
+
+func (graphMemStore) Get(string) string { return "" }
+
+func NewGraphMemStore() *graphMemStore { return &graphMemStore{} }
+
+type GraphAPI struct{}
+
+func NewGraphAPI(cfg *Config, store GraphStore, lazy di.Provider[*LoggerService]) *GraphAPI {
+	return &GraphAPI{}
+}
+
+func newGraphContainer(t *testing.T) *di.Container {
+	t.Helper()
+	c := di.New()
+	if err := c.Init([]interface{}{NewConfig, NewGraphMemStore, NewDatabaseConnection, NewLoggerService}); err != nil {
+		t.Fatalf("Failed to init: %v", err)
+	}
+	if err := di.BindInterfaceTo[GraphStore, *graphMemStore](c); err != nil {
+		t.Fatalf("Failed to bind: %v", err)
+	}
+	if err := di.ProvideTo(c, NewGraphAPI, di.WithLifetime(di.Transient)); err != nil {
+		t.Fatalf("Failed to provide: %v", err)
+	}
+	if err := c.SupplyNamed("replica", &DatabaseConnection{Url: "replica"}); err != nil {
+		t.Fatalf("Failed to supply: %v", err)
+	}
+	return c
+}
+
+func findNode(g *di.Graph, id string) (di.GraphNode, bool) {
+	for _, n := range g.Nodes {
+		if n.ID == id {
+			return n, true
+		}
+	}
+	return di.GraphNode{}, false
+}
+
+func hasEdge(g *di.Graph, from, to string, kind di.EdgeKind) bool {
+	for _, e := range g.Edges {
+		if e.From == from && e.To == to && e.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// TestGraphModel verifies nodes and edges carry scope, qualifiers, bindings and instantiation
+func TestGraphModel(t *testing.T) {
+	c := newGraphContainer(t)
+	if _, err := di.ResolveFrom[*Config](c); err != nil {
+		t.Fatalf("Failed to resolve: %v", err)
+	}
+
+	g := c.Graph()
+
+	cfg, ok := findNode(g, "*main.Config")
+	if !ok || cfg.Scope != "singleton" || !cfg.Instantiated {
+		t.Errorf("Expected instantiated singleton config node, got %+v", cfg)
+	}
+	db, _ := findNode(g, "*main.DatabaseConnection")
+	if db.Instantiated {
+		t.Error("Expected database connection not to be instantiated yet")
+	}
+	api, _ := findNode(g, "*main.GraphAPI")
+	if api.Scope != "transient" {
+		t.Errorf("Expected transient API node, got %q", api.Scope)
+	}
+	replica, ok := findNode(g, "[replica]*main.DatabaseConnection")
+	if !ok || replica.Name != "replica" || !replica.Supplied {
+		t.Errorf("Expected supplied named node, got %+v", replica)
+	}
+
+	if !hasEdge(g, "*main.GraphAPI", "*main.Config", di.EdgeDependency) {
+		t.Error("Expected dependency edge to config")
+	}
+	if !hasEdge(g, "*main.GraphAPI", "main.GraphStore", di.EdgeDependency) {
+		t.Error("Expected dependency edge to the interface")
+	}
+	if !hasEdge(g, "main.GraphStore", "*main.graphMemStore", di.EdgeBinding) {
+		t.Error("Expected binding edge from interface to implementation")
+	}
+	if !hasEdge(g, "*main.GraphAPI", "*main.LoggerService", di.EdgeLazy) {
+		t.Error("Expected lazy edge to logger")
+	}
+}
+
+// TestGraphMissing verifies the graph of an invalid container shows what is missing
+func TestGraphMissing(t *testing.T) {
+	c := di.New()
+	if err := c.Init([]interface{}{NewUserService}); err == nil {
+		t.Fatal("Expected validation error")
+	}
+
+	g := c.Graph()
+	if repo, ok := findNode(g, "*main.UserRepository"); !ok || !repo.Missing {
+		t.Errorf("Expected missing repository node, got %+v", repo)
+	}
+	if !strings.Contains(g.DOT(), "color=red") {
+		t.Error("Expected missing nodes to be highlighted")
+	}
+}
+
+// TestGraphExporters verifies the DOT, Mermaid and JSON renderings
+func TestGraphExporters(t *testing.T) {
+	g := newGraphContainer(t).Graph()
+
+	dot := g.DOT()
+	if !strings.HasPrefix(dot, "digraph dependencies {") ||
+		!strings.Contains(dot, `"*main.GraphAPI" -> "*main.Config";`) ||
+		!strings.Contains(dot, `"main.GraphStore" -> "*main.graphMemStore" [style=bold];`) {
+		t.Errorf("Unexpected DOT output:\n%s", dot)
+	}
+
+	mermaid := g.Mermaid()
+	if !strings.HasPrefix(mermaid, "graph LR\n") || !strings.Contains(mermaid, "==>") || !strings.Contains(mermaid, "-. lazy .->") {
+		t.Errorf("Unexpected Mermaid output:\n%s", mermaid)
+	}
+
+	data, err := g.JSON()
+	if err != nil {
+		t.Fatalf("Failed to export JSON: %v", err)
+	}
+	var decoded di.Graph
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	if len(decoded.Nodes) != len(g.Nodes) || len(decoded.Edges) != len(g.Edges) {
+		t.Error("Expected JSON to round-trip the graph")
+	}
+
+	if g.DOT() != newGraphContainer(t).Graph().DOT() {
+		t.Error("Expected deterministic output")
+	}
+}
+
+// TestGraphDOTQuoting verifies that DOT output keeps non-ASCII names as is
+// and escapes quotes the way Graphviz expects
+func TestGraphDOTQuoting(t *testing.T) {
+	c := di.New()
+	if err := c.SupplyNamed("café\u00a0bar", &DatabaseConnection{}); err != nil {
+		t.Fatalf("Failed to supply: %v", err)
+	}
+	if err := c.SupplyNamed(`say "hi"`, &DatabaseConnection{}); err != nil {
+		t.Fatalf("Failed to supply: %v", err)
+	}
+
+	dot := c.Graph().DOT()
+	if !strings.Contains(dot, "café\u00a0bar") || strings.Contains(dot, `\u00a0`) {
+		t.Errorf("Expected raw non-ASCII names in DOT output:\n%s", dot)
+	}
+	if !strings.Contains(dot, `say \"hi\"`) {
+		t.Errorf("Expected escaped quotes in DOT output:\n%s", dot)
+	}
+	if !strings.Contains(dot, `\nsingleton, supplied`) {
+		t.Errorf("Expected DOT line breaks in labels:\n%s", dot)
+	}
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/binodta/depWeaver/pkg/di"
+)
+
+type HealthChecker interface {
+	Name() string
+}
+
+type dbHealth struct{ cfg *Config }
+type cacheHealth struct{}
+type queueHealth struct{}
+
+func (dbHealth) Name() string    { return "db" }
+func (cacheHealth) Name() string { return "cache" }
+func (queueHealth) Name() string { return "queue" }
+
+func NewDBHealth(cfg *Config) *dbHealth { return &dbHealth{cfg: cfg} }
+func NewCacheHealth() *cacheHealth      { return &cacheHealth{} }
+func NewQueueHealth() queueHealth       { return queueHealth{} }
+
+// sessionHealth is not zero-sized, so separate instances have distinct pointers
+type sessionHealth struct{ checks int }
+
+func (*sessionHealth) Name() string    { return "session" }
+func NewSessionHealth() *sessionHealth { return &sessionHealth{} }
+
+type HealthParams struct {
+	di.In
+	Checkers []HealthChecker `group:"health"`
+}
+
+type HealthEndpoint struct {
+	Checkers []HealthChecker
+}
+
+func NewHealthEndpoint(p HealthParams) *HealthEndpoint {
+	return &HealthEndpoint{Checkers: p.Checkers}
+}
+
+func healthNames(checkers []HealthChecker) string {
+	names := make([]string, len(checkers))
+	for i, c := range checkers {
+		names[i] = c.Name()
+	}
+	return strings.Join(names, ",")
+}
+
+// TestValueGroups verifies group members are injected in registration order
+func TestValueGroups(t *testing.T) {
+	c := di.New()
+
+	// The consumer can be registered before the group has members
+	if err := c.Init([]interface{}{NewConfig, NewHealthEndpoint}); err != nil {
+		t.Fatalf("Failed to init: %v", err)
+	}
+	for _, ctor := range []interface{}{NewDBHealth, NewCacheHealth, NewQueueHealth} {
+		if err := di.ProvideToGroup(c, "health", ctor); err != nil {
+			t.Fatalf("Failed to add group member: %v", err)
+		}
+	}
+
+	endpoint, err := di.ResolveFrom[*HealthEndpoint](c)
+	if err != nil {
+		t.Fatalf("Failed to resolve HealthEndpoint: %v", err)
+	}
+	if got := healthNames(endpoint.Checkers); got != "db,cache,queue" {
+		t.Errorf("Expected deterministic order db,cache,queue, got %s", got)
+	}
+
+	checkers, err := di.ResolveGroupFrom[HealthChecker](c, "health")
+	if err != nil {
+		t.Fatalf("Failed to resolve group: %v", err)
+	}
+	if checkers[0] != endpoint.Checkers[0] {
+		t.Error("Expected singleton group members to be cached")
+	}
+
+	// Group members are not registered as ordinary dependencies
+	if _, err := di.ResolveFrom[*cacheHealth](c); err == nil {
+		t.Error("Expected group member not to be resolvable on its own")
+	}
+}
+
+// TestEmptyValueGroup verifies an empty group resolves to an empty slice
+func TestEmptyValueGroup(t *testing.T) {
+	c := di.New()
+	if err := c.Init([]interface{}{NewHealthEndpoint}); err != nil {
+		t.Fatalf("Failed to init: %v", err)
+	}
+	endpoint, err := di.ResolveFrom[*HealthEndpoint](c)
+	if err != nil {
+		t.Fatalf("Failed to resolve HealthEndpoint: %v", err)
+	}
+	if len(endpoint.Checkers) != 0 {
+		t.Errorf("Expected no checkers, got %d", len(endpoint.Checkers))
+	}
+}
+
+// TestValueGroupValidation verifies each member is validated
+func TestValueGroupValidation(t *testing.T) {
+	c := di.New()
+
+	// NewDBHealth needs *Config, which is not registered
+	if err := di.ProvideToGroup(c, "health", NewDBHealth); err != nil {
+		t.Fatalf("Failed to add group member: %v", err)
+	}
+	err := c.Validate()
+	if err == nil || !strings.Contains(err.Error(), "Config") {
+		t.Fatalf("Expected missing Config error for group member, got: %v", err)
+	}
+
+	c = di.New()
+	di.ProvideToGroup(c, "health", NewConfig) // *Config is not a HealthChecker
+	err = c.Init([]interface{}{NewHealthEndpoint})
+	if err == nil || !strings.Contains(err.Error(), "not assignable") {
+		t.Errorf("Expected assignability error, got: %v", err)
+	}
+}
+
+// TestScopedValueGroup verifies groups with scoped members resolve within a scope
+func TestScopedValueGroup(t *testing.T) {
+	c := di.New()
+	if err := di.ProvideToGroup(c, "health", NewSessionHealth, di.WithLifetime(di.Scoped)); err != nil {
+		t.Fatalf("Failed to add group member: %v", err)
+	}
+	if err := di.ProvideToGroup(c, "health", NewQueueHealth); err != nil {
+		t.Fatalf("Failed to add group member: %v", err)
+	}
+
+	var scopeErr *di.ScopeRequiredError
+	if _, err := di.ResolveGroupFrom[HealthChecker](c, "health"); !errors.As(err, &scopeErr) {
+		t.Fatalf("Expected a ScopeRequiredError without a scope, got: %v", err)
+	}
+
+	first := c.CreateScope()
+	defer c.DestroyScope(first)
+	second := c.CreateScope()
+	defer c.DestroyScope(second)
+
+	a, err := di.ResolveGroupScopedFrom[HealthChecker](c, "health", first)
+	if err != nil {
+		t.Fatalf("Failed to resolve group in scope: %v", err)
+	}
+	if got := healthNames(a); got != "session,queue" {
+		t.Errorf("Expected session,queue, got %s", got)
+	}
+	again, _ := di.ResolveGroupScopedFrom[HealthChecker](c, "health", first)
+	b, _ := di.ResolveGroupScopedFrom[HealthChecker](c, "health", second)
+	if a[0] != again[0] {
+		t.Error("Expected the scoped member to be cached within its scope")
+	}
+	if a[0] == b[0] {
+		t.Error("Expected each scope to get its own scoped member")
+	}
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/binodta/depWeaver/pkg/di"
+)
+
+var hookLog = &closeLog{}
+
+type HookDatabase struct{}
+type HookConsumer struct{ DB *HookDatabase }
+type HookServer struct {
+	DB       *HookDatabase
+	Consumer *HookConsumer
+}
+
+func (d *HookDatabase) Start(ctx context.Context) error { hookLog.add("start:db"); return nil }
+func (d *HookDatabase) Stop(ctx context.Context) error  { hookLog.add("stop:db"); return nil }
+func (c *HookConsumer) Start(ctx context.Context) error { hookLog.add("start:consumer"); return nil }
+func (c *HookConsumer) Stop(ctx context.Context) error  { hookLog.add("stop:consumer"); return nil }
+func (s *HookServer) Start(ctx context.Context) error   { hookLog.add("start:server"); return nil }
+func (s *HookServer) Stop(ctx context.Context) error    { hookLog.add("stop:server"); return nil }
+
+func NewHookDatabase() *HookDatabase                 { return &HookDatabase{} }
+func NewHookConsumer(db *HookDatabase) *HookConsumer { return &HookConsumer{DB: db} }
+func NewHookServer(db *HookDatabase, c *HookConsumer) *HookServer {
+	return &HookServer{DB: db, Consumer: c}
+}
+
+// FailingWorker fails to start, or blocks until its context expires
+type FailingWorker struct{ DB *HookDatabase }
+
+func (w *FailingWorker) Start(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func NewFailingWorker(db *HookDatabase) *FailingWorker { return &FailingWorker{DB: db} }
+
+// HookCache only stops while its context is live
+type HookCache struct{}
+
+func (c *HookCache) Start(ctx context.Context) error { hookLog.add("start:cache"); return nil }
+func (c *HookCache) Stop(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	hookLog.add("stop:cache")
+	return nil
+}
+
+// SlowStarter blocks in Start until released
+type SlowStarter struct{ entered, release chan struct{} }
+
+func (s *SlowStarter) Start(ctx context.Context) error {
+	close(s.entered)
+	<-s.release
+	return nil
+}
+
+// TestStartStopOrder verifies hooks run in dependency order and stop in reverse
+func TestStartStopOrder(t *testing.T) {
+	di.Reset()
+	hookLog = &closeLog{}
+
+	// Registration order is deliberately the reverse of dependency order
+	di.MustInit([]interface{}{NewHookServer, NewHookConsumer, NewHookDatabase})
+
+	if err := di.Start(context.Background()); err != nil {
+		t.Fatalf("Failed to start: %v", err)
+	}
+	if err := di.Stop(context.Background()); err != nil {
+		t.Fatalf("Failed to stop: %v", err)
+	}
+
+	expected := "start:db,start:consumer,start:server,stop:server,stop:consumer,stop:db"
+	if got := strings.Join(hookLog.order, ","); got != expected {
+		t.Errorf("Expected %s, got %s", expected, got)
+	}
+}
+
+// TestStartRollbackOnFailure verifies already-started components are stopped when a hook times out
+func TestStartRollbackOnFailure(t *testing.T) {
+	di.Reset()
+	hookLog = &closeLog{}
+
+	di.MustInit([]interface{}{NewHookDatabase, NewFailingWorker})
+	di.SetHookTimeout(20 * time.Millisecond)
+
+	err := di.Start(context.Background())
+	if err == nil {
+		t.Fatal("Expected start to fail")
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected hook timeout error, got: %v", err)
+	}
+
+	if got := strings.Join(hookLog.order, ","); got != "start:db,stop:db" {
+		t.Errorf("Expected database to be rolled back, got %s", got)
+	}
+}
+
+// TestShutdownStopsBeforeClosing verifies Shutdown stops started components
+func TestShutdownStopsBeforeClosing(t *testing.T) {
+	di.Reset()
+	hookLog = &closeLog{}
+
+	di.MustInit([]interface{}{NewHookDatabase})
+	if err := di.Start(context.Background()); err != nil {
+		t.Fatalf("Failed to start: %v", err)
+	}
+	if err := di.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Failed to shutdown: %v", err)
+	}
+
+	if got := strings.Join(hookLog.order, ","); got != "start:db,stop:db" {
+		t.Errorf("Expected start then stop, got %s", got)
+	}
+}
+
+// TestStartSkipsSuppliedValues verifies values the container does not own are not started
+func TestStartSkipsSuppliedValues(t *testing.T) {
+	hookLog = &closeLog{}
+	c := di.New()
+	if err := c.Supply(&HookDatabase{}); err != nil {
+		t.Fatalf("Failed to supply: %v", err)
+	}
+	if err := di.ProvideTo(c, NewHookConsumer); err != nil {
+		t.Fatalf("Failed to provide: %v", err)
+	}
+
+	if err := c.Start(context.Background()); err != nil {
+		t.Fatalf("Failed to start: %v", err)
+	}
+	if err := c.Stop(context.Background()); err != nil {
+		t.Fatalf("Failed to stop: %v", err)
+	}
+	if got := strings.Join(hookLog.order, ","); got != "start:consumer,stop:consumer" {
+		t.Errorf("Expected only the constructed consumer to be started and stopped, got %s", got)
+	}
+}
+
+// TestStartRollbackAfterDeadline verifies the rollback still stops components once the start context is done
+func TestStartRollbackAfterDeadline(t *testing.T) {
+	hookLog = &closeLog{}
+	c := di.New()
+	err := c.Init([]interface{}{
+		func() *HookCache { return &HookCache{} },
+		func(*HookCache) *FailingWorker { return &FailingWorker{} },
+	})
+	if err != nil {
+		t.Fatalf("Failed to init: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := c.Start(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected the start deadline error, got: %v", err)
+	}
+	if got := strings.Join(hookLog.order, ","); got != "start:cache,stop:cache" {
+		t.Errorf("Expected the cache to be rolled back, got %s", got)
+	}
+}
+
+// TestConcurrentStart verifies a second Start fails while the first is still running
+func TestConcurrentStart(t *testing.T) {
+	starter := &SlowStarter{entered: make(chan struct{}), release: make(chan struct{})}
+	c := di.New()
+	if err := c.Init([]interface{}{func() *SlowStarter { return starter }}); err != nil {
+		t.Fatalf("Failed to init: %v", err)
+	}
+
+	errs := make(chan error, 1)
+	go func() { errs <- c.Start(context.Background()) }()
+	<-starter.entered
+
+	err := c.Start(context.Background())
+	close(starter.release)
+	if err == nil || !strings.Contains(err.Error(), "already started") {
+		t.Errorf("Expected the concurrent start to be refused, got: %v", err)
+	}
+	if err := <-errs; err != nil {
+		t.Errorf("Expected the first start to succeed, got: %v", err)
+	}
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/binodta/depWeaver/pkg/di"
+)
+
+var lazyRepoBuilds int
+
+type LazyRepo struct{ ID int }
+
+func NewLazyRepo() *LazyRepo {
+	lazyRepoBuilds++
+	return &LazyRepo{ID: lazyRepoBuilds}
+}
+
+type ProviderService struct {
+	Repo    di.Provider[*LazyRepo]
+	RepoFn  func() *LazyRepo
+	RepoErr func() (*LazyRepo, error)
+}
+
+func NewProviderService(p di.Provider[*LazyRepo], fn func() *LazyRepo, fnErr func() (*LazyRepo, error)) *ProviderService {
+	return &ProviderService{Repo: p, RepoFn: fn, RepoErr: fnErr}
+}
+
+// Lazy edges break cycles: LazyParent -> LazyChild -> Provider[LazyParent]
+type LazyParent struct{ Child *LazyChild }
+type LazyChild struct{ Parent di.Provider[*LazyParent] }
+
+func NewLazyParent(c *LazyChild) *LazyParent             { return &LazyParent{Child: c} }
+func NewLazyChild(p di.Provider[*LazyParent]) *LazyChild { return &LazyChild{Parent: p} }
+
+// EagerChild calls its provider during construction, which is a real cycle
+type EagerParent struct{}
+type EagerChild struct{}
+
+func NewEagerParent(*EagerChild) *EagerParent { return &EagerParent{} }
+func NewEagerChild(p di.Provider[*EagerParent]) (*EagerChild, error) {
+	if _, err := p.Get(); err != nil {
+		return nil, err
+	}
+	return &EagerChild{}, nil
+}
+
+type ScopedLazyConsumer struct {
+	Request func() (*RequestContext, error)
+}
+
+func NewScopedLazyConsumer(fn func() (*RequestContext, error)) *ScopedLazyConsumer {
+	return &ScopedLazyConsumer{Request: fn}
+}
+
+// TestLazyParams verifies provider and func parameters defer construction
+func TestLazyParams(t *testing.T) {
+	lazyRepoBuilds = 0
+	c := di.New()
+	if err := c.Init([]interface{}{NewLazyRepo, NewProviderService}); err != nil {
+		t.Fatalf("Failed to init: %v", err)
+	}
+
+	svc, err := di.ResolveFrom[*ProviderService](c)
+	if err != nil {
+		t.Fatalf("Failed to resolve: %v", err)
+	}
+	if lazyRepoBuilds != 0 {
+		t.Fatalf("Expected repository not to be built yet, built %d times", lazyRepoBuilds)
+	}
+
+	repo, err := svc.Repo.Get()
+	if err != nil {
+		t.Fatalf("Provider failed: %v", err)
+	}
+	if svc.RepoFn() != repo {
+		t.Error("Expected func() T to return the singleton")
+	}
+	if r, err := svc.RepoErr(); err != nil || r != repo {
+		t.Errorf("Expected func() (T, error) to return the singleton, got %v, %v", r, err)
+	}
+	if lazyRepoBuilds != 1 {
+		t.Errorf("Expected one build, got %d", lazyRepoBuilds)
+	}
+}
+
+// TestLazyParamsBreakCycles verifies lazy edges are not reported as cycles
+func TestLazyParamsBreakCycles(t *testing.T) {
+	c := di.New()
+	if err := c.Init([]interface{}{NewLazyParent, NewLazyChild}); err != nil {
+		t.Fatalf("Expected lazy edge to break the cycle: %v", err)
+	}
+
+	parent, err := di.ResolveFrom[*LazyParent](c)
+	if err != nil {
+		t.Fatalf("Failed to resolve: %v", err)
+	}
+	back, err := parent.Child.Parent.Get()
+	if err != nil {
+		t.Fatalf("Provider failed after construction: %v", err)
+	}
+	if back != parent {
+		t.Error("Expected provider to return the same singleton parent")
+	}
+
+	// Calling the provider while constructing the cycle is still detected
+	c = di.New()
+	if err := c.Init([]interface{}{NewEagerParent, NewEagerChild}); err != nil {
+		t.Fatalf("Failed to init: %v", err)
+	}
+	runWithTimeout(t, 2*time.Second, func() {
+		_, err = di.ResolveFrom[*EagerParent](c)
+	})
+	if err == nil || !strings.Contains(err.Error(), "circular dependency detected") {
+		t.Errorf("Expected circular dependency error, got: %v", err)
+	}
+}
+
+// TestLazyParamsScope verifies lazy accessors stay bound to the scope they were injected in
+func TestLazyParamsScope(t *testing.T) {
+	c := di.New()
+	if err := c.InitWithScope([]di.ScopeRegistration{
+		{Constructor: NewRequestContext, Scope: di.Scoped},
+		{Constructor: NewScopedLazyConsumer, Scope: di.Scoped},
+	}); err != nil {
+		t.Fatalf("Failed to init: %v", err)
+	}
+
+	scopeID := c.CreateScope()
+	defer c.DestroyScope(scopeID)
+
+	consumer, err := di.ResolveScopedFrom[*ScopedLazyConsumer](c, scopeID)
+	if err != nil {
+		t.Fatalf("Failed to resolve: %v", err)
+	}
+	fromFn, err := consumer.Request()
+	if err != nil {
+		t.Fatalf("Lazy scoped resolution failed: %v", err)
+	}
+	direct, _ := di.ResolveScopedFrom[*RequestContext](c, scopeID)
+	if fromFn != direct {
+		t.Error("Expected lazy accessor to resolve within its scope")
+	}
+}
+
+// TestLazyParamsMissing verifies lazy targets must still be registered
+func TestLazyParamsMissing(t *testing.T) {
+	c := di.New()
+	err := c.Init([]interface{}{NewProviderService})
+	if err == nil || !strings.Contains(err.Error(), "LazyRepo") {
+		t.Errorf("Expected missing lazy dependency error, got: %v", err)
+	}
+}
+
+type LazyClock struct{ Now func() time.Time }
+
+// TestLazyParamsRegisteredFunc verifies a registered func type is injected as-is rather than as an accessor
+func TestLazyParamsRegisteredFunc(t *testing.T) {
+	fixed := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	c := di.New()
+	err := c.Init([]interface{}{
+		func(now func() time.Time) *LazyClock { return &LazyClock{Now: now} },
+		func() func() time.Time { return func() time.Time { return fixed } },
+	})
+	if err != nil {
+		t.Fatalf("Failed to init: %v", err)
+	}
+
+	clock, err := di.ResolveFrom[*LazyClock](c)
+	if err != nil {
+		t.Fatalf("Failed to resolve: %v", err)
+	}
+	if got := clock.Now(); !got.Equal(fixed) {
+		t.Errorf("Expected the registered func, got %v", got)
+	}
+}
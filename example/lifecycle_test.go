@@ -0,0 +1,200 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/binodta/depWeaver/pkg/di"
+)
+
+// closeLog records the order in which resources are closed
+type closeLog struct {
+	mu    sync.Mutex
+	order []string
+}
+
+func (l *closeLog) add(name string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.order = append(l.order, name)
+}
+
+var lifecycleLog = &closeLog{}
+
+type LifecyclePool struct{}
+type LifecycleRepo struct{ Pool *LifecyclePool }
+type LifecycleTx struct{ Repo *LifecycleRepo }
+
+func (p *LifecyclePool) Close() error {
+	lifecycleLog.add("pool")
+	return nil
+}
+
+func (r *LifecycleRepo) Close(ctx context.Context) error {
+	lifecycleLog.add("repo")
+	return errors.New("repo flush failed")
+}
+
+func (tx *LifecycleTx) Close() error {
+	lifecycleLog.add("tx")
+	return nil
+}
+
+func NewLifecyclePool() *LifecyclePool                 { return &LifecyclePool{} }
+func NewLifecycleRepo(p *LifecyclePool) *LifecycleRepo { return &LifecycleRepo{Pool: p} }
+func NewLifecycleTx(r *LifecycleRepo) *LifecycleTx     { return &LifecycleTx{Repo: r} }
+
+// TestShutdownClosesInReverseOrder verifies singletons are closed dependents-first with aggregated errors
+func TestShutdownClosesInReverseOrder(t *testing.T) {
+	di.Reset()
+	lifecycleLog = &closeLog{}
+
+	di.MustInitWithScope([]di.ScopeRegistration{
+		{Constructor: NewLifecyclePool, Scope: di.Singleton},
+		{Constructor: NewLifecycleRepo, Scope: di.Singleton},
+		{Constructor: NewLifecycleTx, Scope: di.Scoped},
+	})
+
+	scopeID := di.CreateScope()
+	if _, err := di.ResolveScoped[*LifecycleTx](scopeID); err != nil {
+		t.Fatalf("Failed to resolve LifecycleTx: %v", err)
+	}
+
+	err := di.Shutdown(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "repo flush failed") {
+		t.Fatalf("Expected aggregated close error, got: %v", err)
+	}
+
+	got := strings.Join(lifecycleLog.order, ",")
+	if got != "tx,repo,pool" {
+		t.Errorf("Expected close order tx,repo,pool, got %s", got)
+	}
+
+	// Caches are cleared after shutdown
+	pool, _ := di.Resolve[*LifecyclePool]()
+	if pool == nil {
+		t.Fatal("Expected container to still resolve after shutdown")
+	}
+}
+
+// TestDestroyScopeClosesScopedInstances verifies only the destroyed scope's instances are closed
+func TestDestroyScopeClosesScopedInstances(t *testing.T) {
+	di.Reset()
+	lifecycleLog = &closeLog{}
+
+	di.MustInitWithScope([]di.ScopeRegistration{
+		{Constructor: NewLifecyclePool, Scope: di.Singleton},
+		{Constructor: NewLifecycleRepo, Scope: di.Scoped},
+		{Constructor: NewLifecycleTx, Scope: di.Transient},
+	})
+
+	scope1 := di.CreateScope()
+	scope2 := di.CreateScope()
+	di.ResolveScoped[*LifecycleTx](scope1)
+	di.ResolveScoped[*LifecycleTx](scope2)
+
+	err := di.DestroyScope(scope1)
+	if err == nil || !strings.Contains(err.Error(), scope1) {
+		t.Errorf("Expected scope error mentioning %s, got: %v", scope1, err)
+	}
+
+	// Transient Tx is never owned by the container; singleton pool lives on
+	if got := strings.Join(lifecycleLog.order, ","); got != "repo" {
+		t.Errorf("Expected only scope1's repo to be closed, got %s", got)
+	}
+
+	di.DestroyAllScopes()
+	if got := strings.Join(lifecycleLog.order, ","); got != "repo,repo" {
+		t.Errorf("Expected scope2's repo to be closed, got %s", got)
+	}
+}
+
+// TestCallerSuppliedScopeID verifies scope IDs not created with CreateScope still work
+func TestCallerSuppliedScopeID(t *testing.T) {
+	lifecycleLog = &closeLog{}
+	c := di.New()
+	err := c.InitWithScope([]di.ScopeRegistration{
+		{Constructor: NewLifecyclePool, Scope: di.Singleton},
+		{Constructor: NewLifecycleRepo, Scope: di.Scoped},
+	})
+	if err != nil {
+		t.Fatalf("Failed to init: %v", err)
+	}
+
+	repo, err := di.ResolveScopedFrom[*LifecycleRepo](c, "request-1")
+	if err != nil {
+		t.Fatalf("Failed to resolve in a caller-supplied scope: %v", err)
+	}
+	if again, _ := di.ResolveScopedFrom[*LifecycleRepo](c, "request-1"); again != repo {
+		t.Error("Expected one instance per caller-supplied scope")
+	}
+	_ = c.DestroyScope("request-1")
+	if got := strings.Join(lifecycleLog.order, ","); got != "repo" {
+		t.Errorf("Expected the scoped repo to be closed, got %s", got)
+	}
+}
+
+type LazyConn struct{}
+type LazyConnHolder struct {
+	Conn di.Provider[*LazyConn]
+}
+
+func (c *LazyConn) Close() error {
+	lifecycleLog.add("conn")
+	return nil
+}
+
+func (h *LazyConnHolder) Close() error {
+	lifecycleLog.add("holder")
+	return nil
+}
+
+// TestShutdownOrderWithLazyParams verifies closing follows creation order, not the lazy edge
+func TestShutdownOrderWithLazyParams(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		eager    bool
+		expected string
+	}{
+		// Resolved by the constructor: the connection exists before its holder
+		{"resolved during construction", true, "holder,conn"},
+		// Resolved later: the connection is newer than its holder and is closed first
+		{"resolved after construction", false, "conn,holder"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			lifecycleLog = &closeLog{}
+			c := di.New()
+			err := c.Init([]interface{}{
+				func() *LazyConn { return &LazyConn{} },
+				func(conn di.Provider[*LazyConn]) (*LazyConnHolder, error) {
+					if tc.eager {
+						if _, err := conn.Get(); err != nil {
+							return nil, err
+						}
+					}
+					return &LazyConnHolder{Conn: conn}, nil
+				},
+			})
+			if err != nil {
+				t.Fatalf("Failed to init: %v", err)
+			}
+
+			holder, err := di.ResolveFrom[*LazyConnHolder](c)
+			if err != nil {
+				t.Fatalf("Failed to resolve: %v", err)
+			}
+			if _, err := holder.Conn.Get(); err != nil {
+				t.Fatalf("Failed to resolve lazily: %v", err)
+			}
+			if err := c.Shutdown(context.Background()); err != nil {
+				t.Fatalf("Failed to shutdown: %v", err)
+			}
+			if got := strings.Join(lifecycleLog.order, ","); got != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, got)
+			}
+		})
+	}
+}
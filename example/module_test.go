@@ -0,0 +1,147 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/binodta/depWeaver/pkg/di"
+)
+
+type ModConfig struct{ DSN string }
+type ModDB struct{ Config *ModConfig }
+type ModUserStore interface{ Find(id int) string }
+type ModUserRepo struct{ DB *ModDB }
+type ModLoggingStore struct{ next ModUserStore }
+type ModMailer struct{}
+
+func (r *ModUserRepo) Find(id int) string        { return "user" }
+func (s *ModLoggingStore) Find(id int) string    { return "logged " + s.next.Find(id) }
+func NewModDB(cfg *ModConfig) *ModDB             { return &ModDB{Config: cfg} }
+func NewModUserRepo(db *ModDB) *ModUserRepo      { return &ModUserRepo{DB: db} }
+func WithModLogging(s ModUserStore) ModUserStore { return &ModLoggingStore{next: s} }
+
+var modConfigModule = di.Module("config",
+	di.Provide(func() *ModConfig { return &ModConfig{DSN: "postgres://"} }),
+)
+
+var modStorageModule = di.Module("storage",
+	// Bindings and decorators may precede the constructors they refer to
+	di.Bind[ModUserStore, *ModUserRepo](),
+	di.Decorator(WithModLogging),
+	di.Provide(NewModUserRepo),
+	di.Provide(NewModDB),
+	di.Include(modConfigModule),
+)
+
+// TestModuleInstall verifies a module registers its providers, bindings, decorators and includes
+func TestModuleInstall(t *testing.T) {
+	c := di.New()
+	// The config module is included twice but installed once
+	if err := c.Install(modStorageModule, modConfigModule); err != nil {
+		t.Fatalf("Failed to install: %v", err)
+	}
+
+	store, err := di.ResolveFrom[ModUserStore](c)
+	if err != nil {
+		t.Fatalf("Failed to resolve: %v", err)
+	}
+	if got := store.Find(1); got != "logged user" {
+		t.Errorf("Expected the decorated store, got %q", got)
+	}
+
+	modules := make(map[string]string)
+	for _, node := range c.Graph().Nodes {
+		modules[node.ID] = node.Module
+	}
+	if modules["*main.ModDB"] != "storage" || modules["*main.ModConfig"] != "storage/config" || modules["main.ModUserStore"] != "storage" {
+		t.Errorf("Expected graph nodes to carry their module path, got %v", modules)
+	}
+	if !strings.Contains(c.Graph().DOT(), "module storage") {
+		t.Error("Expected the DOT export to show modules")
+	}
+}
+
+// TestModuleErrorsNameTheModule verifies module names appear in registration, validation and constructor errors
+func TestModuleErrorsNameTheModule(t *testing.T) {
+	broken := di.Module("broken", di.Provide("not a constructor"))
+	err := di.New().Install(di.Module("app", di.Include(broken)))
+	if err == nil || !strings.Contains(err.Error(), "module app/broken") {
+		t.Errorf("Expected the registration error to name the module, got %v", err)
+	}
+
+	notify := di.Module("notify", di.Provide(func(*ModMailer) *ModUserRepo { return &ModUserRepo{} }))
+	err = di.New().Install(notify)
+	var missing *di.MissingDependencyError
+	if !errors.As(err, &missing) || !strings.Contains(err.Error(), "in module notify") {
+		t.Errorf("Expected the consumer's module in the validation report, got %v", err)
+	}
+
+	failing := di.Module("failing", di.Provide(func() (*ModMailer, error) { return nil, errors.New("smtp down") }))
+	c := di.New()
+	if err := c.Install(failing); err != nil {
+		t.Fatalf("Failed to install: %v", err)
+	}
+	_, err = di.ResolveFrom[*ModMailer](c)
+	var ctorErr *di.ConstructorError
+	if !errors.As(err, &ctorErr) || ctorErr.Module != "failing" || !strings.Contains(err.Error(), "(module failing)") {
+		t.Errorf("Expected a constructor error naming the module, got %v", err)
+	}
+}
+
+// TestModuleGroupMembers verifies modules contribute value group members with InGroup
+func TestModuleGroupMembers(t *testing.T) {
+	cacheModule := di.Module("cache", di.Provide(NewCacheHealth, di.InGroup("health")))
+	queueModule := di.Module("queue", di.Provide(NewQueueHealth, di.InGroup("health")))
+
+	c := di.New()
+	err := c.Install(
+		di.Module("app", di.Provide(NewHealthEndpoint), di.Include(cacheModule)),
+		queueModule,
+	)
+	if err != nil {
+		t.Fatalf("Failed to install: %v", err)
+	}
+
+	endpoint, err := di.ResolveFrom[*HealthEndpoint](c)
+	if err != nil {
+		t.Fatalf("Failed to resolve: %v", err)
+	}
+	if got := healthNames(endpoint.Checkers); got != "cache,queue" {
+		t.Errorf("Expected the members of both modules in install order, got %s", got)
+	}
+
+	// Group members stay unnamed
+	broken := di.Module("broken", di.Provide(NewCacheHealth, di.InGroup("health"), di.WithName("cache")))
+	if err := di.New().Install(broken); err == nil || !strings.Contains(err.Error(), "module broken") {
+		t.Errorf("Expected a module error for a named group member, got: %v", err)
+	}
+}
+
+// TestModuleInstallIsAtomic verifies a failing module leaves nothing behind
+func TestModuleInstallIsAtomic(t *testing.T) {
+	c := di.New()
+	// *ModLoggingStore has no constructor, so the binding fails after the constructors are registered
+	broken := di.Module("storage",
+		di.Provide(NewModUserRepo),
+		di.Provide(NewModDB),
+		di.Bind[ModUserStore, *ModLoggingStore](),
+		di.Include(modConfigModule),
+	)
+	if err := c.Install(broken); err == nil || !strings.Contains(err.Error(), "module storage") {
+		t.Fatalf("Expected the binding error, got %v", err)
+	}
+	if _, err := di.ResolveFrom[*ModDB](c); err == nil {
+		t.Error("Expected the constructors of the failed install to be removed")
+	}
+
+	// Validation failures are undone too
+	if err := c.Install(di.Module("repo", di.Provide(NewModUserRepo))); err == nil {
+		t.Fatal("Expected a validation error for the missing *ModDB")
+	}
+
+	// Nothing is left to clash with a fixed install
+	if err := c.Install(modStorageModule); err != nil {
+		t.Fatalf("Expected the fixed module to install cleanly, got %v", err)
+	}
+}
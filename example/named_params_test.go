@@ -0,0 +1,103 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/binodta/depWeaver/pkg/di"
+)
+
+type ReplicaDB struct {
+	DSN string
+}
+
+type ReportStore struct {
+	Writer *ReplicaDB
+	Reader *ReplicaDB
+}
+
+type ReportReader interface {
+	Read() string
+}
+
+type replicaReader struct{ db *ReplicaDB }
+
+func (r *replicaReader) Read() string { return r.db.DSN }
+
+func NewPrimaryDB() *ReplicaDB { return &ReplicaDB{DSN: "primary"} }
+func NewReplicaDB() *ReplicaDB { return &ReplicaDB{DSN: "replica"} }
+
+func NewReportStore(writer *ReplicaDB, reader *ReplicaDB) *ReportStore {
+	return &ReportStore{Writer: writer, Reader: reader}
+}
+
+// TestNamedParameterInjection verifies WithParamNames selects named dependencies per parameter
+func TestNamedParameterInjection(t *testing.T) {
+	c := di.New()
+
+	if err := di.ProvideTo(c, NewPrimaryDB); err != nil {
+		t.Fatalf("Failed to provide primary: %v", err)
+	}
+	if err := di.ProvideTo(c, NewReplicaDB, di.WithName("replica")); err != nil {
+		t.Fatalf("Failed to provide replica: %v", err)
+	}
+	if err := di.ProvideTo(c, NewReportStore, di.WithParamNames("", "replica")); err != nil {
+		t.Fatalf("Failed to provide store: %v", err)
+	}
+
+	store, err := di.ResolveFrom[*ReportStore](c)
+	if err != nil {
+		t.Fatalf("Failed to resolve ReportStore: %v", err)
+	}
+	if store.Writer.DSN != "primary" {
+		t.Errorf("Expected primary writer, got %s", store.Writer.DSN)
+	}
+	if store.Reader.DSN != "replica" {
+		t.Errorf("Expected replica reader, got %s", store.Reader.DSN)
+	}
+}
+
+// TestNamedParameterValidation verifies Validate honors parameter names
+func TestNamedParameterValidation(t *testing.T) {
+	c := di.New()
+
+	// A named interface parameter with no named binding must fail validation
+	NewReaderUser := func(r ReportReader) *ReportStore { return &ReportStore{} }
+	if err := di.ProvideTo(c, NewReaderUser, di.WithParamNames("replica")); err != nil {
+		t.Fatalf("Failed to register: %v", err)
+	}
+	err := c.Validate()
+	if err == nil {
+		t.Fatal("Expected validation error for missing named interface binding")
+	}
+	if !strings.Contains(err.Error(), "replica") {
+		t.Errorf("Expected error to mention the parameter name, got: %v", err)
+	}
+
+	// Too many parameter names are rejected at registration
+	err = di.ProvideTo(di.New(), NewPrimaryDB, di.WithParamNames("extra"))
+	if err == nil {
+		t.Error("Expected error for more parameter names than parameters")
+	}
+}
+
+// TestNamedParameterCycle verifies cycles through named nodes are detected
+func TestNamedParameterCycle(t *testing.T) {
+	c := di.New()
+
+	NewSelfNamed := func(db *ReplicaDB) *ReplicaDB { return db }
+	if err := di.ProvideTo(c, NewSelfNamed, di.WithName("loop"), di.WithParamNames("loop")); err != nil {
+		t.Fatalf("Failed to register: %v", err)
+	}
+	err := c.Validate()
+	if err == nil || !strings.Contains(err.Error(), "circular dependency detected") {
+		t.Fatalf("Expected circular dependency error, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "[loop]*main.ReplicaDB") {
+		t.Errorf("Expected named node in the chain, got: %v", err)
+	}
+
+	if _, err := di.ResolveNamedFrom[*ReplicaDB](c, "loop"); err == nil {
+		t.Error("Expected resolution of a named cycle to fail instead of deadlocking")
+	}
+}
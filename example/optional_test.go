@@ -0,0 +1,94 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/binodta/depWeaver/pkg/di"
+)
+
+type OptTracer struct{}
+
+type OptMetrics interface {
+	Count(name string)
+}
+
+type optStatsd struct{}
+
+func (optStatsd) Count(string) {}
+
+type OptServer struct {
+	Config  *Config
+	Tracer  di.Optional[*OptTracer]
+	Metrics di.Optional[OptMetrics]
+}
+
+func NewOptTracer() *OptTracer { return &OptTracer{} }
+
+func NewOptServer(cfg *Config, tracer di.Optional[*OptTracer], metrics di.Optional[OptMetrics]) *OptServer {
+	return &OptServer{Config: cfg, Tracer: tracer, Metrics: metrics}
+}
+
+type OptParams struct {
+	di.In
+	Tracer di.Optional[*OptTracer] `name:"tracing"`
+}
+
+func NewOptParamsUser(p OptParams) *OptTracer {
+	return p.Tracer.Value
+}
+
+// TestOptionalAbsent verifies unregistered optional dependencies resolve without error
+func TestOptionalAbsent(t *testing.T) {
+	c := di.New()
+	if err := c.Init([]interface{}{NewConfig, NewOptServer}); err != nil {
+		t.Fatalf("Expected validation to skip optional dependencies: %v", err)
+	}
+
+	server, err := di.ResolveFrom[*OptServer](c)
+	if err != nil {
+		t.Fatalf("Failed to resolve: %v", err)
+	}
+	if server.Tracer.Ok || server.Tracer.Value != nil {
+		t.Error("Expected absent tracer")
+	}
+	if server.Metrics.Ok || server.Metrics.Value != nil {
+		t.Error("Expected absent metrics")
+	}
+}
+
+// TestOptionalPresent verifies registered optional dependencies are injected
+func TestOptionalPresent(t *testing.T) {
+	c := di.New()
+	if err := c.Init([]interface{}{NewConfig, NewOptTracer, NewOptServer}); err != nil {
+		t.Fatalf("Failed to init: %v", err)
+	}
+	if err := di.SupplyAsTo[OptMetrics](c, optStatsd{}); err != nil {
+		t.Fatalf("Failed to supply metrics: %v", err)
+	}
+
+	server, err := di.ResolveFrom[*OptServer](c)
+	if err != nil {
+		t.Fatalf("Failed to resolve: %v", err)
+	}
+	if !server.Tracer.Ok || server.Tracer.Value == nil {
+		t.Error("Expected tracer to be injected")
+	}
+	if !server.Metrics.Ok {
+		t.Error("Expected metrics to be injected")
+	}
+}
+
+// TestOptionalField verifies di.Optional works inside parameter objects
+func TestOptionalField(t *testing.T) {
+	c := di.New()
+	if err := di.ProvideTo(c, NewOptParamsUser, di.WithName("user")); err != nil {
+		t.Fatalf("Failed to provide: %v", err)
+	}
+	tracer, err := di.ResolveNamedFrom[*OptTracer](c, "user")
+	if err != nil {
+		t.Fatalf("Failed to resolve: %v", err)
+	}
+	if tracer != nil {
+		t.Error("Expected nil tracer when absent")
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/binodta/depWeaver/pkg/di"
+)
+
+type PanicPool struct{}
+type PanicRepo struct{ Pool *PanicPool }
+
+var errPanicPoolExhausted = errors.New("pool exhausted")
+
+func NewPanicRepo(p *PanicPool) *PanicRepo { return &PanicRepo{Pool: p} }
+
+// TestConstructorPanicRecovered verifies a panicking constructor becomes a path-aware error
+func TestConstructorPanicRecovered(t *testing.T) {
+	c := di.New()
+	err := c.Init([]interface{}{
+		func() *PanicPool { panic(errPanicPoolExhausted) },
+		NewPanicRepo,
+	})
+	if err != nil {
+		t.Fatalf("Failed to init: %v", err)
+	}
+
+	_, err = di.ResolveFrom[*PanicRepo](c)
+	var panicErr *di.ConstructorPanicError
+	if !errors.As(err, &panicErr) {
+		t.Fatalf("Expected a ConstructorPanicError, got %v", err)
+	}
+	if panicErr.Value != errPanicPoolExhausted || !errors.Is(err, errPanicPoolExhausted) {
+		t.Errorf("Expected the panic value to be kept and unwrapped, got %v", panicErr.Value)
+	}
+	if strings.Join(panicErr.Path, " -> ") != "*main.PanicRepo -> *main.PanicPool" {
+		t.Errorf("Expected the resolution path, got %v", panicErr.Path)
+	}
+	if !strings.Contains(string(panicErr.Stack), "panic_test.go") {
+		t.Errorf("Expected the stack trace to include the panicking constructor, got:\n%s", panicErr.Stack)
+	}
+
+	// The failed build must not leave the singleton marked in progress
+	if _, err := di.ResolveFrom[*PanicPool](c); !errors.As(err, &panicErr) {
+		t.Errorf("Expected the panic again on the next resolve, got %v", err)
+	}
+}
+
+// TestDecoratorPanicRecovered verifies decorators are covered too
+func TestDecoratorPanicRecovered(t *testing.T) {
+	c := di.New()
+	if err := c.Init([]interface{}{func() *PanicPool { return &PanicPool{} }}); err != nil {
+		t.Fatalf("Failed to init: %v", err)
+	}
+	if err := c.Decorate(func(*PanicPool) *PanicPool { panic("bad decorator") }); err != nil {
+		t.Fatalf("Failed to decorate: %v", err)
+	}
+
+	_, err := di.ResolveFrom[*PanicPool](c)
+	var panicErr *di.ConstructorPanicError
+	if !errors.As(err, &panicErr) || panicErr.Value != "bad decorator" {
+		t.Fatalf("Expected the decorator panic, got %v", err)
+	}
+}
+
+// TestConstructorPanicCrash verifies SetCrashOnPanic lets the panic propagate
+func TestConstructorPanicCrash(t *testing.T) {
+	c := di.New()
+	c.SetCrashOnPanic(true)
+	if err := c.Init([]interface{}{func() *PanicPool { panic("boom") }}); err != nil {
+		t.Fatalf("Failed to init: %v", err)
+	}
+
+	func() {
+		defer func() {
+			if r := recover(); r != "boom" {
+				t.Errorf("Expected the original panic, got %v", r)
+			}
+		}()
+		_, _ = di.ResolveFrom[*PanicPool](c)
+	}()
+
+	// The in-progress marker was released while unwinding, so the next resolve does not hang
+	c.SetCrashOnPanic(false)
+	if _, err := di.ResolveFrom[*PanicPool](c); err == nil {
+		t.Error("Expected the recovered panic as an error")
+	}
+}
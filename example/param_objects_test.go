@@ -0,0 +1,115 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/binodta/depWeaver/pkg/di"
+)
+
+type ObjUserStore struct{ DSN string }
+type ObjOrderStore struct{ DSN string }
+type ObjTracer struct{}
+
+// ObjStores provides several types from a single constructor
+type ObjStores struct {
+	di.Out
+	Users  *ObjUserStore
+	Orders *ObjOrderStore `name:"orders"`
+}
+
+// ObjServerParams collects the server's dependencies
+type ObjServerParams struct {
+	di.In
+	Config *Config
+	Users  *ObjUserStore
+	Orders *ObjOrderStore `name:"orders"`
+	Tracer *ObjTracer     `optional:"true"`
+}
+
+type ObjServer struct {
+	Params ObjServerParams
+}
+
+var objStoresCount int
+
+func NewObjStores(cfg *Config) ObjStores {
+	objStoresCount++
+	return ObjStores{
+		Users:  &ObjUserStore{DSN: cfg.DatabaseURL},
+		Orders: &ObjOrderStore{DSN: cfg.DatabaseURL + "/orders"},
+	}
+}
+
+func NewObjServer(p ObjServerParams) *ObjServer {
+	return &ObjServer{Params: p}
+}
+
+// TestParamAndResultObjects verifies di.In fields are injected and di.Out fields are registered
+func TestParamAndResultObjects(t *testing.T) {
+	di.Reset()
+	objStoresCount = 0
+
+	if err := di.Init([]interface{}{NewConfig, NewObjStores, NewObjServer}); err != nil {
+		t.Fatalf("Failed to init: %v", err)
+	}
+
+	server, err := di.Resolve[*ObjServer]()
+	if err != nil {
+		t.Fatalf("Failed to resolve ObjServer: %v", err)
+	}
+	if server.Params.Config == nil || server.Params.Users == nil || server.Params.Orders == nil {
+		t.Fatal("Expected all required parameter object fields to be injected")
+	}
+	if server.Params.Tracer != nil {
+		t.Error("Expected unregistered optional field to be left nil")
+	}
+	if !strings.HasSuffix(server.Params.Orders.DSN, "/orders") {
+		t.Errorf("Expected named orders store, got %s", server.Params.Orders.DSN)
+	}
+
+	// Fields share the result object's singleton
+	users, _ := di.Resolve[*ObjUserStore]()
+	orders, _ := di.ResolveNamed[*ObjOrderStore]("orders")
+	if users != server.Params.Users || orders != server.Params.Orders {
+		t.Error("Expected result object fields to be cached singletons")
+	}
+	if objStoresCount != 1 {
+		t.Errorf("Expected result object constructor to run once, got %d", objStoresCount)
+	}
+
+	// Optional fields are injected once registered
+	if err := di.RegisterRuntime(func() *ObjTracer { return &ObjTracer{} }, di.Singleton); err != nil {
+		t.Fatalf("Failed to register tracer: %v", err)
+	}
+	if err := di.Override(NewObjServer, di.Transient); err != nil {
+		t.Fatalf("Failed to override server: %v", err)
+	}
+	server, _ = di.Resolve[*ObjServer]()
+	if server.Params.Tracer == nil {
+		t.Error("Expected registered optional field to be injected")
+	}
+}
+
+// TestParamObjectValidation verifies parameter object fields are validated
+func TestParamObjectValidation(t *testing.T) {
+	di.Reset()
+
+	// Orders is registered under "orders" only via ObjStores, which is missing here
+	err := di.Init([]interface{}{NewConfig, NewObjServer})
+	if err == nil {
+		t.Fatal("Expected validation error for missing parameter object fields")
+	}
+	if !strings.Contains(err.Error(), "ObjUserStore") && !strings.Contains(err.Error(), "ObjOrderStore") {
+		t.Errorf("Expected error to name a missing field type, got: %v", err)
+	}
+
+	type badParams struct {
+		di.In
+		hidden *Config
+	}
+	err = di.RegisterRuntime(func(p badParams) *ObjTracer { return nil }, di.Singleton)
+	if err == nil || !strings.Contains(err.Error(), "must be exported") {
+		t.Errorf("Expected unexported field error, got: %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/binodta/depWeaver/pkg/di"
+)
+
+type LazyService struct {
+	dbProvider di.Provider[*Database]
+}
+
+// TestPublicScopeConstants verifies registration using only the public di package
+func TestPublicScopeConstants(t *testing.T) {
+	di.Reset()
+
+	var scope di.Scope = di.Scoped
+	err := di.InitWithScope([]di.ScopeRegistration{
+		{Constructor: NewCounter, Scope: di.Singleton},
+		{Constructor: NewDatabase, Scope: di.Transient},
+		{Constructor: NewRequestContext, Scope: scope},
+	})
+	if err != nil {
+		t.Fatalf("Failed to init with public scopes: %v", err)
+	}
+
+	db1, _ := di.Resolve[*Database]()
+	db2, _ := di.Resolve[*Database]()
+	if db1 == db2 {
+		t.Error("Expected different instances for di.Transient")
+	}
+
+	scopeID := di.CreateScope()
+	defer di.DestroyScope(scopeID)
+	if _, err := di.ResolveScoped[*RequestContext](scopeID); err != nil {
+		t.Fatalf("Failed to resolve di.Scoped dependency: %v", err)
+	}
+}
+
+// TestPublicProvider verifies that di.Provider can be stored and used lazily
+func TestPublicProvider(t *testing.T) {
+	di.Reset()
+	dbConnectionCounter = 0
+
+	di.MustInit([]interface{}{NewDatabase})
+
+	svc := &LazyService{dbProvider: di.GetProvider[*Database]("")}
+	if dbConnectionCounter != 0 {
+		t.Fatalf("Expected no construction before Get, got %d", dbConnectionCounter)
+	}
+
+	db, err := svc.dbProvider.Get()
+	if err != nil {
+		t.Fatalf("Failed to get Database from provider: %v", err)
+	}
+	if db == nil || dbConnectionCounter != 1 {
+		t.Errorf("Expected one constructed Database, got %d", dbConnectionCounter)
+	}
+}
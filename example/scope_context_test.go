@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/binodta/depWeaver/pkg/di"
+)
+
+type CtxScopeSession struct{ closed *atomic.Int32 }
+
+func (s *CtxScopeSession) Close() error {
+	s.closed.Add(1)
+	return nil
+}
+
+type CtxScopeFailingCloser struct{}
+
+func (*CtxScopeFailingCloser) Close() error { return errors.New("close failed") }
+
+func newCtxScopeContainer(t *testing.T, closed *atomic.Int32) *di.Container {
+	t.Helper()
+	c := di.New()
+	err := c.InitWithScope([]di.ScopeRegistration{
+		{Constructor: func() *CtxScopeSession { return &CtxScopeSession{closed: closed} }, Scope: di.Scoped},
+	})
+	if err != nil {
+		t.Fatalf("Failed to init: %v", err)
+	}
+	return c
+}
+
+// TestWithScopeResolvesFromContext verifies the context carries a scope shared by its resolutions
+func TestWithScopeResolvesFromContext(t *testing.T) {
+	var closed atomic.Int32
+	c := newCtxScopeContainer(t, &closed)
+
+	ctx, release := c.WithScope(context.Background())
+	s1, err := di.ResolveFromContext[*CtxScopeSession](ctx)
+	if err != nil {
+		t.Fatalf("Failed to resolve: %v", err)
+	}
+	s2, _ := di.ResolveFromContext[*CtxScopeSession](ctx)
+	if s1 != s2 {
+		t.Error("Expected one instance per context scope")
+	}
+
+	other, releaseOther := c.WithScope(context.Background())
+	defer releaseOther()
+	s3, _ := di.ResolveFromContext[*CtxScopeSession](other)
+	if s1 == s3 {
+		t.Error("Expected separate scopes to get separate instances")
+	}
+
+	release()
+	release()
+	if closed.Load() != 1 {
+		t.Errorf("Expected the scope's instance to be closed once, got %d", closed.Load())
+	}
+	if _, err := di.ResolveFromContext[*CtxScopeSession](ctx); err == nil {
+		t.Error("Expected resolving from a destroyed scope to fail")
+	}
+}
+
+// TestResolveFromContextWithoutScope verifies a plain context is rejected
+func TestResolveFromContextWithoutScope(t *testing.T) {
+	_, err := di.ResolveFromContext[*CtxScopeSession](context.Background())
+	if !errors.Is(err, di.ErrNoScope) {
+		t.Errorf("Expected ErrNoScope, got %v", err)
+	}
+	if _, ok := di.ScopeFromContext(context.Background()); ok {
+		t.Error("Expected no scope in a plain context")
+	}
+}
+
+// TestWithScopeDestroyOnDone verifies the scope is destroyed when the parent context ends
+func TestWithScopeDestroyOnDone(t *testing.T) {
+	var closed atomic.Int32
+	c := newCtxScopeContainer(t, &closed)
+
+	parent, cancel := context.WithCancel(context.Background())
+	ctx, release := c.WithScope(parent, di.DestroyOnDone())
+	defer release()
+	if _, err := di.ResolveFromContext[*CtxScopeSession](ctx); err != nil {
+		t.Fatalf("Failed to resolve: %v", err)
+	}
+
+	cancel()
+	deadline := time.Now().Add(time.Second)
+	for closed.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if closed.Load() != 1 {
+		t.Fatalf("Expected the scope to be destroyed when the context was canceled, got %d closes", closed.Load())
+	}
+
+	release()
+	if closed.Load() != 1 {
+		t.Errorf("Expected release after automatic destruction to be a no-op, got %d closes", closed.Load())
+	}
+}
+
+// TestWithScopeReportsDestroyErrors verifies close errors reach OnDestroyError
+func TestWithScopeReportsDestroyErrors(t *testing.T) {
+	c := di.New()
+	if err := c.RegisterRuntime(func() *CtxScopeFailingCloser { return &CtxScopeFailingCloser{} }, di.Scoped); err != nil {
+		t.Fatalf("Failed to register: %v", err)
+	}
+
+	var reported error
+	ctx, release := c.WithScope(context.Background(), di.OnDestroyError(func(scopeID string, err error) {
+		reported = err
+	}))
+	if _, err := di.ResolveFromContext[*CtxScopeFailingCloser](ctx); err != nil {
+		t.Fatalf("Failed to resolve: %v", err)
+	}
+	release()
+	if reported == nil {
+		t.Error("Expected the close error to be reported")
+	}
+}
+
+type CtxScopeSlowConn struct{ closed *atomic.Int32 }
+
+func (c *CtxScopeSlowConn) Close() error {
+	c.closed.Add(1)
+	return nil
+}
+
+// TestWithScopeDestroyedDuringBuild verifies an instance finished after its scope was destroyed is closed, not cached
+func TestWithScopeDestroyedDuringBuild(t *testing.T) {
+	var sessionClosed, connClosed atomic.Int32
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	c := newCtxScopeContainer(t, &sessionClosed)
+	err := c.RegisterRuntime(func() *CtxScopeSlowConn {
+		close(entered)
+		<-release
+		return &CtxScopeSlowConn{closed: &connClosed}
+	}, di.Scoped)
+	if err != nil {
+		t.Fatalf("Failed to register: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	scoped, releaseScope := c.WithScope(ctx, di.DestroyOnDone())
+	defer releaseScope()
+	// The session is closed by the scope's destruction, which tells us when it has happened
+	if _, err := di.ResolveFromContext[*CtxScopeSession](scoped); err != nil {
+		t.Fatalf("Failed to resolve: %v", err)
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		_, err := di.ResolveFromContext[*CtxScopeSlowConn](scoped)
+		errs <- err
+	}()
+	<-entered
+	cancel()
+	for sessionClosed.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+
+	var notFound *di.ScopeNotFoundError
+	if err := <-errs; !errors.As(err, &notFound) {
+		t.Errorf("Expected the late instance to be refused, got %v", err)
+	}
+	if connClosed.Load() != 1 {
+		t.Errorf("Expected the late instance to be closed, got %d closes", connClosed.Load())
+	}
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/binodta/depWeaver/pkg/di"
+)
+
+type ChainConfig struct{}
+type ChainSession struct{ Config *ChainConfig }
+type ChainUnitOfWork struct{ Session *ChainSession }
+type ChainHandler struct {
+	Work    *ChainUnitOfWork
+	Session *ChainSession
+}
+
+var chainSessionCount atomic.Int32
+
+func NewChainConfig() *ChainConfig { return &ChainConfig{} }
+func NewChainSession(cfg *ChainConfig) *ChainSession {
+	chainSessionCount.Add(1)
+	time.Sleep(5 * time.Millisecond)
+	return &ChainSession{Config: cfg}
+}
+func NewChainUnitOfWork(s *ChainSession) *ChainUnitOfWork { return &ChainUnitOfWork{Session: s} }
+func NewChainHandler(w *ChainUnitOfWork, s *ChainSession) *ChainHandler {
+	return &ChainHandler{Work: w, Session: s}
+}
+
+// runWithTimeout fails the test instead of hanging forever if fn deadlocks
+func runWithTimeout(t *testing.T, timeout time.Duration, fn func()) {
+	t.Helper()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn()
+	}()
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		t.Fatal("resolution did not finish in time (deadlock?)")
+	}
+}
+
+// TestScopedConstructorWithDependencies verifies that a scoped constructor can resolve its own parameters
+func TestScopedConstructorWithDependencies(t *testing.T) {
+	di.Reset()
+	chainSessionCount.Store(0)
+
+	di.MustInitWithScope([]di.ScopeRegistration{
+		{Constructor: NewChainConfig, Scope: di.Singleton},
+		{Constructor: NewChainSession, Scope: di.Scoped},
+		{Constructor: NewChainUnitOfWork, Scope: di.Scoped},
+		{Constructor: NewChainHandler, Scope: di.Transient},
+	})
+
+	scopeID := di.CreateScope()
+	defer di.DestroyScope(scopeID)
+
+	runWithTimeout(t, 5*time.Second, func() {
+		handler, err := di.ResolveScoped[*ChainHandler](scopeID)
+		if err != nil {
+			t.Errorf("Failed to resolve ChainHandler: %v", err)
+			return
+		}
+		if handler.Work.Session != handler.Session {
+			t.Error("Expected the same scoped session throughout the chain")
+		}
+	})
+}
+
+// TestConcurrentScopedChains resolves deep scoped chains from many goroutines across several scopes
+func TestConcurrentScopedChains(t *testing.T) {
+	di.Reset()
+	chainSessionCount.Store(0)
+
+	di.MustInitWithScope([]di.ScopeRegistration{
+		{Constructor: NewChainConfig, Scope: di.Singleton},
+		{Constructor: NewChainSession, Scope: di.Scoped},
+		{Constructor: NewChainUnitOfWork, Scope: di.Scoped},
+		{Constructor: NewChainHandler, Scope: di.Transient},
+	})
+
+	const numScopes = 5
+	const perScope = 20
+
+	scopes := make([]string, numScopes)
+	for i := range scopes {
+		scopes[i] = di.CreateScope()
+	}
+	defer di.DestroyAllScopes()
+
+	sessions := make([][]*ChainSession, numScopes)
+	for i := range sessions {
+		sessions[i] = make([]*ChainSession, perScope)
+	}
+
+	runWithTimeout(t, 5*time.Second, func() {
+		var wg sync.WaitGroup
+		for s := 0; s < numScopes; s++ {
+			for g := 0; g < perScope; g++ {
+				wg.Add(1)
+				go func(s, g int) {
+					defer wg.Done()
+					handler, err := di.ResolveScoped[*ChainHandler](scopes[s])
+					if err != nil {
+						t.Errorf("scope %d goroutine %d: %v", s, g, err)
+						return
+					}
+					sessions[s][g] = handler.Session
+				}(s, g)
+			}
+		}
+		wg.Wait()
+	})
+
+	for s := 0; s < numScopes; s++ {
+		for g := 1; g < perScope; g++ {
+			if sessions[s][g] != sessions[s][0] {
+				t.Fatalf("scope %d: goroutine %d got a different session", s, g)
+			}
+		}
+	}
+	if got := chainSessionCount.Load(); got != numScopes {
+		t.Errorf("Expected %d sessions (one per scope), got %d", numScopes, got)
+	}
+}
+
+// TestConcurrentNamedSingletonWithDependencies verifies named singletons can resolve parameters concurrently
+func TestConcurrentNamedSingletonWithDependencies(t *testing.T) {
+	di.Reset()
+	chainSessionCount.Store(0)
+
+	di.MustInit([]interface{}{NewChainConfig})
+	if err := di.RegisterNamedConstructor("primary", NewChainSession, di.Singleton); err != nil {
+		t.Fatalf("Failed to register named session: %v", err)
+	}
+	if err := di.RegisterNamedConstructor("scoped", NewChainSession, di.Scoped); err != nil {
+		t.Fatalf("Failed to register named scoped session: %v", err)
+	}
+
+	scopeID := di.CreateScope()
+	defer di.DestroyScope(scopeID)
+
+	const numGoroutines = 50
+	results := make([]*ChainSession, numGoroutines)
+	scopedResults := make([]*ChainSession, numGoroutines)
+
+	runWithTimeout(t, 5*time.Second, func() {
+		var wg sync.WaitGroup
+		wg.Add(numGoroutines)
+		for i := 0; i < numGoroutines; i++ {
+			go func(idx int) {
+				defer wg.Done()
+				s, err := di.ResolveNamed[*ChainSession]("primary")
+				if err != nil {
+					t.Errorf("goroutine %d: %v", idx, err)
+					return
+				}
+				results[idx] = s
+				s, err = di.ResolveNamedScoped[*ChainSession]("scoped", scopeID)
+				if err != nil {
+					t.Errorf("goroutine %d (scoped): %v", idx, err)
+					return
+				}
+				scopedResults[idx] = s
+			}(i)
+		}
+		wg.Wait()
+	})
+
+	for i := 1; i < numGoroutines; i++ {
+		if results[i] != results[0] || scopedResults[i] != scopedResults[0] {
+			t.Fatalf("goroutine %d got a different named instance", i)
+		}
+	}
+	if got := chainSessionCount.Load(); got != 2 {
+		t.Errorf("Expected 2 named sessions to be constructed, got %d", got)
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/binodta/depWeaver/pkg/di"
+)
+
+type LocQueue struct{}
+type LocWorker struct{ Queue *LocQueue }
+type LocLoopA struct{}
+type LocLoopB struct{}
+
+func NewLocWorker(q *LocQueue) *LocWorker { return &LocWorker{Queue: q} }
+func NewLocQueue() (*LocQueue, error)     { return nil, errors.New("broker unreachable") }
+func NewLocLoopA(*LocLoopB) *LocLoopA     { return &LocLoopA{} }
+func NewLocLoopB(*LocLoopA) *LocLoopB     { return &LocLoopB{} }
+
+// TestSourceLocationsMissing verifies missing dependencies point at the consumer's constructor and registration
+func TestSourceLocationsMissing(t *testing.T) {
+	err := di.New().Init([]interface{}{NewLocWorker})
+
+	var missing *di.MissingDependencyError
+	if !errors.As(err, &missing) {
+		t.Fatalf("Expected a missing dependency, got %v", err)
+	}
+	location := missing.Locations["*main.LocWorker"]
+	if !strings.Contains(location, "example.NewLocWorker (source_locations_test.go:16)") ||
+		!strings.Contains(location, "registered at source_locations_test.go:23") {
+		t.Errorf("Expected the constructor and the Init call, got %q", location)
+	}
+	if !strings.Contains(err.Error(), "where *main.LocWorker is "+location) {
+		t.Errorf("Expected the location in the message, got %v", err)
+	}
+}
+
+// TestSourceLocationsCycle verifies every node of a cycle is located
+func TestSourceLocationsCycle(t *testing.T) {
+	c := di.New()
+	_ = c.Init([]interface{}{NewLocLoopA, NewLocLoopB})
+
+	_, err := di.ResolveFrom[*LocLoopA](c)
+	var cycle *di.CycleError
+	if !errors.As(err, &cycle) {
+		t.Fatalf("Expected a cycle, got %v", err)
+	}
+	for _, want := range []string{"example.NewLocLoopA (source_locations_test.go:18)", "example.NewLocLoopB (source_locations_test.go:19)"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected %q in %v", want, err)
+		}
+	}
+}
+
+// TestSourceLocationsConstructorFailure verifies constructor errors carry the failing function and the chain
+func TestSourceLocationsConstructorFailure(t *testing.T) {
+	c := di.New()
+	if err := c.Init([]interface{}{NewLocWorker, NewLocQueue}); err != nil {
+		t.Fatalf("Failed to init: %v", err)
+	}
+
+	_, err := di.ResolveFrom[*LocWorker](c)
+	var ctorErr *di.ConstructorError
+	if !errors.As(err, &ctorErr) {
+		t.Fatalf("Expected a constructor error, got %v", err)
+	}
+	if ctorErr.Location != "source_locations_test.go:17" {
+		t.Errorf("Expected the constructor's file:line, got %q", ctorErr.Location)
+	}
+	if strings.Join(ctorErr.Path, " -> ") != "*main.LocWorker -> *main.LocQueue" {
+		t.Errorf("Expected the resolution path, got %v", ctorErr.Path)
+	}
+	if !strings.Contains(ctorErr.Locations["*main.LocWorker"], "registered at source_locations_test.go:59") {
+		t.Errorf("Expected the consumer's registration, got %v", ctorErr.Locations)
+	}
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/binodta/depWeaver/pkg/di"
+)
+
+type SuppliedSettings struct {
+	Env string
+}
+
+type SuppliedGreeter interface {
+	Greet() string
+}
+
+type englishGreeter struct{}
+
+func (englishGreeter) Greet() string { return "hello" }
+
+type GreetingService struct {
+	Settings *SuppliedSettings
+	Greeter  SuppliedGreeter
+}
+
+func NewGreetingService(s *SuppliedSettings, g SuppliedGreeter) *GreetingService {
+	return &GreetingService{Settings: s, Greeter: g}
+}
+
+// ClosableSettings records whether the container closed it
+type ClosableSettings struct{ closed bool }
+
+func (c *ClosableSettings) Close() error {
+	c.closed = true
+	return nil
+}
+
+// TestSupplyValues verifies pre-built values and interface values are injected
+func TestSupplyValues(t *testing.T) {
+	di.Reset()
+
+	settings := &SuppliedSettings{Env: "prod"}
+	if err := di.Supply(settings); err != nil {
+		t.Fatalf("Failed to supply settings: %v", err)
+	}
+	if err := di.SupplyAs[SuppliedGreeter](englishGreeter{}); err != nil {
+		t.Fatalf("Failed to supply greeter: %v", err)
+	}
+	if err := di.Init([]interface{}{NewGreetingService}); err != nil {
+		t.Fatalf("Failed to init: %v", err)
+	}
+
+	svc, err := di.Resolve[*GreetingService]()
+	if err != nil {
+		t.Fatalf("Failed to resolve GreetingService: %v", err)
+	}
+	if svc.Settings != settings {
+		t.Error("Expected the supplied settings instance to be injected")
+	}
+	if svc.Greeter.Greet() != "hello" {
+		t.Error("Expected supplied greeter to be injected under its interface type")
+	}
+}
+
+// TestSupplyNamedAndOverride verifies named values and overriding a supplied value with a constructor
+func TestSupplyNamedAndOverride(t *testing.T) {
+	di.Reset()
+
+	if err := di.SupplyNamed("staging", &SuppliedSettings{Env: "staging"}); err != nil {
+		t.Fatalf("Failed to supply named settings: %v", err)
+	}
+	staging, err := di.ResolveNamed[*SuppliedSettings]("staging")
+	if err != nil || staging.Env != "staging" {
+		t.Fatalf("Failed to resolve named settings: %v", err)
+	}
+
+	di.Supply(&SuppliedSettings{Env: "prod"})
+	err = di.Override(func() *SuppliedSettings { return &SuppliedSettings{Env: "test"} }, di.Singleton)
+	if err != nil {
+		t.Fatalf("Failed to override supplied value: %v", err)
+	}
+	overridden, _ := di.Resolve[*SuppliedSettings]()
+	if overridden.Env != "test" {
+		t.Errorf("Expected overridden settings, got %s", overridden.Env)
+	}
+}
+
+// TestSupplyValidation verifies supplied values participate in validation and are not closed
+func TestSupplyValidation(t *testing.T) {
+	di.Reset()
+
+	err := di.Init([]interface{}{NewGreetingService})
+	if err == nil || !strings.Contains(err.Error(), "SuppliedSettings") {
+		t.Fatalf("Expected missing SuppliedSettings error, got: %v", err)
+	}
+
+	if err := di.Supply(nil); err == nil {
+		t.Error("Expected error when supplying nil")
+	}
+
+	closable := &ClosableSettings{}
+	di.Supply(closable)
+	di.Resolve[*ClosableSettings]()
+	di.Shutdown(context.Background())
+	if closable.closed {
+		t.Error("Expected supplied value not to be closed by the container")
+	}
+}
+
+// TestSupplyAnyOrder verifies supplying a value does not validate a graph that is still being wired
+func TestSupplyAnyOrder(t *testing.T) {
+	c := di.New()
+	if err := di.ProvideTo(c, NewGreetingService); err != nil {
+		t.Fatalf("Failed to provide: %v", err)
+	}
+	// SuppliedGreeter is still missing at this point
+	if err := c.Supply(&SuppliedSettings{Env: "dev"}); err != nil {
+		t.Fatalf("Expected supplying before the graph is complete to succeed, got: %v", err)
+	}
+	if err := di.SupplyAsTo[SuppliedGreeter](c, englishGreeter{}); err != nil {
+		t.Fatalf("Failed to supply greeter: %v", err)
+	}
+	if err := c.Validate(); err != nil {
+		t.Fatalf("Expected the completed graph to validate, got: %v", err)
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/binodta/depWeaver/pkg/di"
+)
+
+var errDialFailed = errors.New("dial failed")
+
+type TypedConn struct{}
+type TypedRepo struct{}
+type TypedHandler struct{}
+
+func NewFailingTypedConn() (*TypedConn, error) { return nil, errDialFailed }
+func NewTypedRepo(*TypedConn) *TypedRepo       { return &TypedRepo{} }
+func NewTypedHandler(*TypedRepo) *TypedHandler { return &TypedHandler{} }
+
+// TestMissingDependencyError verifies missing dependencies are typed for Resolve and Validate
+func TestMissingDependencyError(t *testing.T) {
+	c := di.New()
+	validateErr := c.Init([]interface{}{NewTypedRepo, NewTypedHandler})
+
+	var missing *di.MissingDependencyError
+	if !errors.As(validateErr, &missing) {
+		t.Fatalf("Expected MissingDependencyError from Validate, got %T: %v", validateErr, validateErr)
+	}
+	if missing.Type != reflect.TypeOf(&TypedConn{}) || strings.Join(missing.RequestedBy, ",") != "*main.TypedRepo" {
+		t.Errorf("Unexpected missing dependency: %+v", missing)
+	}
+
+	_, err := di.ResolveFrom[*TypedHandler](c)
+	if !errors.As(err, &missing) {
+		t.Fatalf("Expected MissingDependencyError from Resolve, got %T: %v", err, err)
+	}
+	if strings.Join(missing.Path, " -> ") != "*main.TypedHandler -> *main.TypedRepo" {
+		t.Errorf("Expected resolution path, got %v", missing.Path)
+	}
+}
+
+// TestCycleError verifies cycles are typed for Resolve and Validate
+func TestCycleError(t *testing.T) {
+	c := di.New()
+	validateErr := c.Init([]interface{}{NewServiceA, NewServiceB})
+
+	var cycle *di.CycleError
+	if !errors.As(validateErr, &cycle) {
+		t.Fatalf("Expected CycleError from Validate, got %T: %v", validateErr, validateErr)
+	}
+
+	_, err := di.ResolveFrom[*ServiceA](c)
+	if !errors.As(err, &cycle) {
+		t.Fatalf("Expected CycleError from Resolve, got %T: %v", err, err)
+	}
+	if len(cycle.Path) != 3 || cycle.Path[0] != cycle.Path[2] {
+		t.Errorf("Expected closed cycle path, got %v", cycle.Path)
+	}
+}
+
+// TestConstructorError verifies constructor failures keep their cause
+func TestConstructorError(t *testing.T) {
+	c := di.New()
+	if err := c.Init([]interface{}{NewFailingTypedConn, NewTypedRepo}); err != nil {
+		t.Fatalf("Failed to init: %v", err)
+	}
+
+	_, err := di.ResolveFrom[*TypedRepo](c)
+	var ctorErr *di.ConstructorError
+	if !errors.As(err, &ctorErr) {
+		t.Fatalf("Expected ConstructorError, got %T: %v", err, err)
+	}
+	if ctorErr.Type != reflect.TypeOf(&TypedConn{}) || !strings.HasSuffix(ctorErr.Func, "NewFailingTypedConn") {
+		t.Errorf("Unexpected constructor error: %+v", ctorErr)
+	}
+	if !errors.Is(err, errDialFailed) {
+		t.Error("Expected errors.Is to reach the constructor's error")
+	}
+}
+
+// TestScopeRequiredError verifies scoped dependencies resolved without a scope are typed
+func TestScopeRequiredError(t *testing.T) {
+	c := di.New()
+	if err := c.InitWithScope([]di.ScopeRegistration{{Constructor: NewRequestContext, Scope: di.Scoped}}); err != nil {
+		t.Fatalf("Failed to init: %v", err)
+	}
+
+	_, err := di.ResolveFrom[*RequestContext](c)
+	var scopeErr *di.ScopeRequiredError
+	if !errors.As(err, &scopeErr) {
+		t.Fatalf("Expected ScopeRequiredError, got %T: %v", err, err)
+	}
+	if scopeErr.Type != reflect.TypeOf(&RequestContext{}) {
+		t.Errorf("Unexpected type %v", scopeErr.Type)
+	}
+}
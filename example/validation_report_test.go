@@ -0,0 +1,89 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/binodta/depWeaver/pkg/di"
+)
+
+type ReportMailer struct{}
+type ReportBilling struct{}
+type ReportSignup struct{}
+
+type ReportQueue interface {
+	Push(msg string)
+}
+
+type ReportCycleA struct{}
+type ReportCycleB struct{}
+
+func NewReportBilling(*ReportMailer, *Config) *ReportBilling      { return &ReportBilling{} }
+func NewReportSignup(*ReportMailer, ReportQueue) *ReportSignup    { return &ReportSignup{} }
+func NewReportCycleA(*ReportCycleB) *ReportCycleA                 { return &ReportCycleA{} }
+func NewReportCycleB(*ReportCycleA, *LoggerService) *ReportCycleB { return &ReportCycleB{} }
+
+// TestValidationReportCollectsAll verifies every problem is reported at once, with consumers
+func TestValidationReportCollectsAll(t *testing.T) {
+	c := di.New()
+	err := c.Init([]interface{}{NewReportBilling, NewReportSignup, NewReportCycleA, NewReportCycleB})
+	if err == nil {
+		t.Fatal("Expected validation to fail")
+	}
+
+	var report *di.ValidationReport
+	if !errors.As(err, &report) {
+		t.Fatalf("Expected a *di.ValidationReport, got %T", err)
+	}
+
+	// *ReportMailer, *Config, ReportQueue and *LoggerService are missing
+	if len(report.Missing) != 4 {
+		t.Fatalf("Expected 4 missing dependencies, got %d: %v", len(report.Missing), err)
+	}
+	mailer := report.Missing[2]
+	if mailer.Type != reflect.TypeOf(&ReportMailer{}) {
+		t.Fatalf("Expected sorted missing dependencies, got %v at index 2", mailer.Type)
+	}
+	if strings.Join(mailer.RequestedBy, ",") != "*main.ReportBilling,*main.ReportSignup" {
+		t.Errorf("Expected both consumers of the mailer, got %v", mailer.RequestedBy)
+	}
+
+	// The cycle is reported once, whichever node it was entered from
+	if len(report.Cycles) != 1 {
+		t.Fatalf("Expected one distinct cycle, got %d", len(report.Cycles))
+	}
+	if path := report.Cycles[0].Path; len(path) != 3 || path[0] != path[2] {
+		t.Errorf("Expected a closed two-node cycle path, got %v", path)
+	}
+
+	msg := err.Error()
+	for _, want := range []string{
+		"dependency graph has 5 problems",
+		"circular dependency detected",
+		"no binding found for interface main.ReportQueue (required by *main.ReportSignup)",
+		"no constructor registered for type *main.ReportMailer",
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("Expected error to contain %q, got:\n%s", want, msg)
+		}
+	}
+
+	// Individual problems are reachable with errors.As
+	var cycle *di.CycleError
+	if !errors.As(err, &cycle) {
+		t.Error("Expected errors.As to find the cycle")
+	}
+}
+
+// TestValidationReportValid verifies a valid graph produces an empty report
+func TestValidationReportValid(t *testing.T) {
+	c := di.New()
+	if err := c.Init([]interface{}{NewConfig, NewLoggerService}); err != nil {
+		t.Fatalf("Failed to init: %v", err)
+	}
+	if report := c.ValidationReport(); !report.Valid() {
+		t.Errorf("Expected valid report, got: %v", report)
+	}
+}
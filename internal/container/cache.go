@@ -0,0 +1,85 @@
+package container
+
+import "reflect"
+
+// lookupInstance returns the cached instance for key. Callers must hold dc.mu.
+func (dc *DependencyContainer) lookupInstance(key instanceKey) (interface{}, bool) {
+	var dep interface{}
+	var exists bool
+
+	switch {
+	case key.group != "":
+		dep, exists = dc.groupInstances[key]
+	case key.scopeID == "" && key.name == "":
+		dep, exists = dc.dependencies[key.t]
+	case key.name == "":
+		dep, exists = dc.scopedInstances[key.scopeID][key.t]
+	case key.scopeID == "":
+		dep, exists = dc.namedDependencies[key.name][key.t]
+	default:
+		dep, exists = dc.namedScopedInstances[key.scopeID][key.name][key.t]
+	}
+
+	return dep, exists
+}
+
+// storeInstance caches an instance under key, creating nested maps as needed. Callers must hold dc.mu (write).
+func (dc *DependencyContainer) storeInstance(key instanceKey, instance interface{}) {
+	dc.trackDisposable(key, instance)
+
+	switch {
+	case key.group != "":
+		dc.groupInstances[key] = instance
+	case key.scopeID == "" && key.name == "":
+		dc.dependencies[key.t] = instance
+	case key.name == "":
+		if dc.scopedInstances[key.scopeID] == nil {
+			dc.scopedInstances[key.scopeID] = make(map[reflect.Type]interface{})
+		}
+		dc.scopedInstances[key.scopeID][key.t] = instance
+	case key.scopeID == "":
+		if dc.namedDependencies[key.name] == nil {
+			dc.namedDependencies[key.name] = make(map[reflect.Type]interface{})
+		}
+		dc.namedDependencies[key.name][key.t] = instance
+	default:
+		if dc.namedScopedInstances[key.scopeID] == nil {
+			dc.namedScopedInstances[key.scopeID] = make(map[string]map[reflect.Type]interface{})
+		}
+		if dc.namedScopedInstances[key.scopeID][key.name] == nil {
+			dc.namedScopedInstances[key.scopeID][key.name] = make(map[reflect.Type]interface{})
+		}
+		dc.namedScopedInstances[key.scopeID][key.name][key.t] = instance
+	}
+}
+
+// forgetFailures drops every cached build error, as a registration change may fix what failed.
+// Callers must hold dc.mu (write).
+func (dc *DependencyContainer) forgetFailures() {
+	clear(dc.failures)
+}
+
+// evictInstances drops the cached instances of t and of the interfaces bound to t: a decorated
+// interface is cached under the interface itself and wraps an instance of t. Callers must hold dc.mu (write).
+func (dc *DependencyContainer) evictInstances(t reflect.Type) {
+	types := []reflect.Type{t}
+	for iface, concrete := range dc.interfaceBindings {
+		if concrete == t {
+			types = append(types, iface)
+		}
+	}
+	for _, t := range types {
+		delete(dc.dependencies, t)
+		for _, scopeCache := range dc.scopedInstances {
+			delete(scopeCache, t)
+		}
+	}
+}
+
+// evictNamedInstances drops the cached instances of t registered under name. Callers must hold dc.mu (write).
+func (dc *DependencyContainer) evictNamedInstances(name string, t reflect.Type) {
+	delete(dc.namedDependencies[name], t)
+	for _, scopeCache := range dc.namedScopedInstances {
+		delete(scopeCache[name], t)
+	}
+}
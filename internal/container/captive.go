@@ -0,0 +1,121 @@
+package container
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// CapturePolicy decides how Validate treats a singleton that depends on a transient
+type CapturePolicy int
+
+const (
+	CaptureWarn  CapturePolicy = iota // Report the edge in ValidationReport.Warnings (default)
+	CaptureAllow                      // Do not report the edge
+	CaptureError                      // Fail validation
+)
+
+// CaptiveDependencyError reports a singleton that depends on a shorter-lived registration.
+// The singleton is built once, so it keeps the first instance of that dependency forever.
+type CaptiveDependencyError struct {
+	Path  []string // From the singleton to the captured dependency
+	Scope Scope    // Lifetime of the captured dependency
+}
+
+func (e *CaptiveDependencyError) Error() string {
+	return fmt.Sprintf("captive dependency: singleton %s captures %s %s: %s",
+		e.Path[0], e.Scope, e.Path[len(e.Path)-1], strings.Join(e.Path, " -> "))
+}
+
+// SetTransientCapturePolicy sets how Validate treats singletons that depend on transients.
+// Singletons depending on scoped registrations always fail validation.
+func (dc *DependencyContainer) SetTransientCapturePolicy(policy CapturePolicy) {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+	dc.transientCapture = policy
+}
+
+// checkCaptives reports every singleton that depends, directly or through transients, on a
+// scoped or transient registration. Lazy edges only capture scoped registrations.
+func (v *validator) checkCaptives() {
+	var roots []nodeKey
+	for t := range v.dc.constructors {
+		roots = append(roots, nodeKey{t: t})
+	}
+	for name, nameMap := range v.dc.namedConstructors {
+		for t := range nameMap {
+			roots = append(roots, nodeKey{t: t, name: name})
+		}
+	}
+	for group, members := range v.dc.groups {
+		for i := range members {
+			roots = append(roots, v.dc.groupMemberKey(group, i))
+		}
+	}
+	for t := range v.dc.decorators {
+		if t.Kind() == reflect.Interface {
+			roots = append(roots, nodeKey{t: t})
+		}
+	}
+
+	seen := make(map[nodeKey]bool)
+	for _, key := range roots {
+		owner, key, reg, ok := v.dc.lookupOwner(key)
+		if !ok || owner != v.dc || reg.scope != Singleton || seen[key] {
+			continue
+		}
+		seen[key] = true
+		v.walkCaptives(owner, []nodeKey{key}, reg, false, make(map[nodeKey]bool))
+	}
+}
+
+// walkCaptives follows the dependencies of reg, registered in owner. Dependencies served by an
+// ancestor container are followed in the ancestor's graph. Behind a lazy edge (lazy is true),
+// transients are built afresh on every call and only scoped dependencies are captured: the
+// accessor keeps the scope of the resolution that built the singleton.
+func (v *validator) walkCaptives(owner *DependencyContainer, path []nodeKey, reg *Registration, lazy bool, visited map[nodeKey]bool) {
+	for _, dep := range v.servedDependencies(owner, reg) {
+		depOwner, target, depReg, ok := v.lookup(owner, dep.key)
+		if !ok || visited[target] {
+			continue // Missing dependencies and cycles are reported elsewhere
+		}
+		visited[target] = true
+		depPath := append(append([]nodeKey{}, path...), target)
+
+		switch depReg.scope {
+		case Scoped:
+			v.report.Captive = append(v.report.Captive, &CaptiveDependencyError{Path: stackPath(depPath), Scope: Scoped})
+		case Transient:
+			// Value groups are assembled by a synthetic transient; only their members matter
+			if !lazy && dep.lazy == nil && (target.group == "" || target.index > 0) {
+				err := &CaptiveDependencyError{Path: stackPath(depPath), Scope: Transient}
+				switch v.dc.transientCapture {
+				case CaptureWarn:
+					v.report.Warnings = append(v.report.Warnings, err)
+				case CaptureError:
+					v.report.Captive = append(v.report.Captive, err)
+				}
+			}
+			// A transient built for the singleton captures its own dependencies too
+			v.walkCaptives(depOwner, depPath, depReg, lazy || dep.lazy != nil, visited)
+		}
+	}
+}
+
+// dependencies runs servedDependencies on any container of the hierarchy being validated
+func (v *validator) servedDependencies(dc *DependencyContainer, reg *Registration) []dependency {
+	if dc != v.dc {
+		dc.mu.RLock()
+		defer dc.mu.RUnlock()
+	}
+	return dc.servedDependencies(reg)
+}
+
+// lookup runs lookupOwner on any container of the hierarchy being validated
+func (v *validator) lookup(dc *DependencyContainer, key nodeKey) (*DependencyContainer, nodeKey, *Registration, bool) {
+	if dc != v.dc {
+		dc.mu.RLock()
+		defer dc.mu.RUnlock()
+	}
+	return dc.lookupOwner(key)
+}
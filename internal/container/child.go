@@ -0,0 +1,116 @@
+package container
+
+import "reflect"
+
+// NewChild creates a container layered on top of dc. Registrations in the child override or
+// extend dc's; anything the child does not register itself is resolved by dc, which builds
+// and caches it in its own graph. Child value groups contain dc's members followed by their own.
+// dc keeps track of the child so that destroying a scope also closes the child's instances for it.
+func (dc *DependencyContainer) NewChild() *DependencyContainer {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+
+	child := New()
+	child.parent = dc
+	child.hookTimeout = dc.hookTimeout
+	child.transientCapture = dc.transientCapture
+	child.crashOnPanic = dc.crashOnPanic
+	child.failurePolicy = dc.failurePolicy
+	dc.children = append(dc.children, child)
+	return child
+}
+
+// servesLocally reports whether dc itself registers key, ignoring its ancestors.
+// Whole value groups are always assembled locally. Callers must hold dc.mu (read).
+func (dc *DependencyContainer) servesLocally(key nodeKey) bool {
+	switch {
+	case key.group != "":
+		if key.index == 0 {
+			return true
+		}
+		inherited := dc.inheritedMemberCount(key.group)
+		return key.index > inherited && key.index <= inherited+len(dc.groups[key.group])
+	case key.name != "":
+		if _, ok := dc.namedInterfaceBindings[key.name][key.t]; ok {
+			return true
+		}
+		_, ok := dc.namedConstructors[key.name][key.t]
+		return ok
+	default:
+		if _, ok := dc.constructors[key.t]; ok {
+			return true
+		}
+		if _, ok := dc.interfaceBindings[key.t]; ok {
+			return true
+		}
+		return len(dc.decorators[key.t]) > 0
+	}
+}
+
+// delegate returns the nearest ancestor that registers key if dc does not, or nil if key is
+// dc's to resolve (including when nobody registers it). Callers must hold dc.mu (read).
+func (dc *DependencyContainer) delegate(key nodeKey) *DependencyContainer {
+	if dc.servesLocally(key) {
+		return nil
+	}
+	return dc.ancestor(key)
+}
+
+// ancestor returns the nearest ancestor of dc that registers key, or nil
+func (dc *DependencyContainer) ancestor(key nodeKey) *DependencyContainer {
+	for p := dc.parent; p != nil; p = p.parent {
+		p.mu.RLock()
+		local := p.servesLocally(key)
+		p.mu.RUnlock()
+		if local {
+			return p
+		}
+	}
+	return nil
+}
+
+// lookupOwner is lookupRegistration across the container hierarchy: it also returns the container
+// whose registration serves key. Callers must hold dc.mu (read).
+func (dc *DependencyContainer) lookupOwner(key nodeKey) (*DependencyContainer, nodeKey, *Registration, bool) {
+	if owner := dc.delegate(key); owner != nil {
+		owner.mu.RLock()
+		defer owner.mu.RUnlock()
+		return owner.lookupOwner(key)
+	}
+
+	resolved, reg, ok := dc.lookupRegistration(key)
+	if !ok && resolved != key {
+		// A local binding or the named fallback may lead to a type registered by an ancestor
+		return dc.lookupOwner(resolved)
+	}
+	return dc, resolved, reg, ok
+}
+
+// inheritedMemberCount returns how many members of a value group come from dc's ancestors.
+// They take the first indexes of the group. Callers must hold dc.mu (read).
+func (dc *DependencyContainer) inheritedMemberCount(group string) int {
+	return len(dc.inheritedMemberTypes(group))
+}
+
+// inheritedMemberTypes returns the member types of a value group registered by dc's ancestors,
+// in index order. Callers must hold dc.mu (read).
+func (dc *DependencyContainer) inheritedMemberTypes(group string) []reflect.Type {
+	if dc.parent == nil {
+		return nil
+	}
+	p := dc.parent
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	types := p.inheritedMemberTypes(group)
+	for _, m := range p.groups[group] {
+		types = append(types, m.t)
+	}
+	return types
+}
+
+// groupMemberKey returns the graph node of the i-th locally registered member of a value group.
+// Callers must hold dc.mu (read).
+func (dc *DependencyContainer) groupMemberKey(group string, i int) nodeKey {
+	return nodeKey{t: dc.groups[group][i].t, group: group, index: dc.inheritedMemberCount(group) + i + 1}
+}
@@ -1,8 +1,11 @@
 package container
 
 import (
+	"context"
+	"fmt"
 	"reflect"
 	"sync"
+	"time"
 )
 
 // Scope defines the lifetime of a dependency
@@ -14,26 +17,138 @@ const (
 	Scoped                 // Created once per scope context
 )
 
+func (s Scope) String() string {
+	switch s {
+	case Singleton:
+		return "singleton"
+	case Transient:
+		return "transient"
+	case Scoped:
+		return "scoped"
+	default:
+		return fmt.Sprintf("Scope(%d)", int(s))
+	}
+}
+
 // Registration holds constructor and scope information
 type Registration struct {
-	constructor func(container *DependencyContainer, scopeID string, stack []reflect.Type) (interface{}, error)
+	constructor func(ctx context.Context, container *DependencyContainer, scopeID string, stack []nodeKey) (interface{}, error)
 	scope       Scope
 	paramTypes  []reflect.Type // Metadata for validation and analysis
+	params      []param        // How each parameter is resolved (names, di.In fields)
+	supplied    bool           // Pre-built value registered via RegisterInstance (not owned by the container)
+	instance    interface{}    // The supplied value
+	scopedValue bool           // Supplied to each scope via SupplyScopedValue instead of constructed
+	module      string         // Module the registration comes from ("" if none)
+	fn          reflect.Value  // The constructor function (invalid for values)
+	source      string         // Constructor function and its file:line ("" for values)
+	caller      string         // file:line of the application code that registered it
+}
+
+// describe names where a registration comes from for diagnostics
+func (r *Registration) describe() string {
+	var desc string
+	switch {
+	case r.supplied:
+		desc = fmt.Sprintf("supplied value %T", r.instance)
+	case r.scopedValue:
+		desc = "scoped value"
+	default:
+		desc = r.source
+	}
+	if r.caller != "" {
+		desc += ", registered at " + r.caller
+	}
+	if r.module != "" {
+		desc += " in module " + r.module
+	}
+	return desc
+}
+
+// dependencies returns every value the constructor resolves, with di.In parameter objects flattened
+func (r *Registration) dependencies() []dependency {
+	var deps []dependency
+	for _, p := range r.params {
+		deps = append(deps, p.dependencies()...)
+	}
+	return deps
+}
+
+// nodeKey identifies a node of the dependency graph: a type with an optional name qualifier.
+// Value groups use group instead of name: index 0 is the whole group (a slice type),
+// index i > 0 is the i-th member in registration order.
+type nodeKey struct {
+	t     reflect.Type
+	name  string
+	group string
+	index int
+}
+
+func (k nodeKey) String() string {
+	switch {
+	case k.group != "" && k.index > 0:
+		return fmt.Sprintf("[group:%s#%d]%v", k.group, k.index, k.t)
+	case k.group != "":
+		return fmt.Sprintf("[group:%s]%v", k.group, k.t)
+	case k.name != "":
+		return fmt.Sprintf("[%s]%v", k.name, k.t)
+	default:
+		return k.t.String()
+	}
+}
+
+// instanceKey identifies a cached instance by (scope, name, type).
+// Singletons use an empty scopeID and unnamed registrations an empty name.
+// Group members are identified by group and member ID instead of name.
+type instanceKey struct {
+	scopeID string
+	name    string
+	group   string
+	member  uint64 // ID of a group member (see groupMember)
+	t       reflect.Type
 }
 
 type DependencyContainer struct {
 	mu              sync.RWMutex
 	dependencies    map[reflect.Type]interface{}            // Singleton cache
 	constructors    map[reflect.Type]*Registration          // Constructor registrations with scope
-	inProgress      map[reflect.Type]chan struct{}          // Track singletons being created for waiting
+	inProgress      map[instanceKey]*flight                 // Builds in progress, shared with waiting callers
 	scopedInstances map[string]map[reflect.Type]interface{} // Scoped instances by context ID
 
 	// Interface and Named bindings
 	interfaceBindings      map[reflect.Type]reflect.Type                      // Unnamed interface -> concrete type bindings
 	namedInterfaceBindings map[string]map[reflect.Type]reflect.Type           // Named bindings: name -> (interface -> concrete)
+	bindingModules         map[bindingRef]string                              // Module each binding comes from (absent if none)
 	namedConstructors      map[string]map[reflect.Type]*Registration          // Named concrete type constructors
 	namedDependencies      map[string]map[reflect.Type]interface{}            // Named singleton cache: name -> type -> instance
 	namedScopedInstances   map[string]map[string]map[reflect.Type]interface{} // Named scoped cache: scopeID -> name -> type -> instance
+
+	// Value groups
+	groups         map[string][]groupMember    // Group members in registration order
+	groupInstances map[instanceKey]interface{} // Cached group members (singleton and scoped)
+
+	// Decorators by decorated type, in registration order
+	decorators map[reflect.Type][]*decorator
+
+	// Lifecycle tracking (creation order)
+	disposables       []interface{}            // Closable singletons (named and unnamed)
+	scopedDisposables map[string][]interface{} // Closable scoped instances by scope ID
+	running           []interface{}            // Singletons visited by Start, in startup order
+	starting          bool                     // Start is in progress
+	hookTimeout       time.Duration            // Per-hook limit for Start/Stop (0 = none)
+
+	transientCapture CapturePolicy         // How Validate treats singletons depending on transients
+	crashOnPanic     bool                  // Let constructor panics propagate instead of recovering them
+	failurePolicy    FailurePolicy         // How failed builds of cached instances are handled
+	failures         map[instanceKey]error // Cached build errors (CacheFailure only)
+
+	parent   *DependencyContainer   // Resolves whatever this container does not register (nil for a root)
+	children []*DependencyContainer // Containers created with NewChild; they cache instances for the same scopes
+
+	// Scope teardown bookkeeping, kept by the root container for the whole hierarchy
+	scopeMu         sync.Mutex
+	scopeBuilds     map[string]int      // Builds in progress per scope ID
+	destroyedScopes map[string]struct{} // Scopes destroyed while builds for them were in progress
 }
 
 // New creates a new dependency container
@@ -41,12 +156,17 @@ func New() *DependencyContainer {
 	return &DependencyContainer{
 		dependencies:           make(map[reflect.Type]interface{}),
 		constructors:           make(map[reflect.Type]*Registration),
-		inProgress:             make(map[reflect.Type]chan struct{}),
+		inProgress:             make(map[instanceKey]*flight),
 		scopedInstances:        make(map[string]map[reflect.Type]interface{}),
 		interfaceBindings:      make(map[reflect.Type]reflect.Type),
 		namedInterfaceBindings: make(map[string]map[reflect.Type]reflect.Type),
+		bindingModules:         make(map[bindingRef]string),
 		namedConstructors:      make(map[string]map[reflect.Type]*Registration),
 		namedDependencies:      make(map[string]map[reflect.Type]interface{}),
 		namedScopedInstances:   make(map[string]map[string]map[reflect.Type]interface{}),
+		groups:                 make(map[string][]groupMember),
+		groupInstances:         make(map[instanceKey]interface{}),
+		decorators:             make(map[reflect.Type][]*decorator),
+		scopedDisposables:      make(map[string][]interface{}),
 	}
 }
@@ -0,0 +1,178 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// decorator wraps the instance of an already-registered type. Its first parameter receives
+// the instance being decorated; the remaining parameters are resolved from the container.
+type decorator struct {
+	fn     reflect.Value
+	fnType reflect.Type
+	params []param
+	module string // Module the decorator comes from ("" if none)
+}
+
+// RegisterDecorator adds a decorator of the form func(T, deps...) T or func(T, deps...) (T, error).
+// Decorators of the same type are applied in registration order, and the decorated instance is
+// cached according to the scope of T's registration.
+func (dc *DependencyContainer) RegisterDecorator(fn interface{}) error {
+	return dc.RegisterModuleDecorator("", fn)
+}
+
+// RegisterModuleDecorator adds a decorator on behalf of a module (see RegisterDecorator)
+func (dc *DependencyContainer) RegisterModuleDecorator(module string, fn interface{}) error {
+	fnType := reflect.TypeOf(fn)
+	if fnType == nil || fnType.Kind() != reflect.Func {
+		return fmt.Errorf("decorator must be a function, got %T", fn)
+	}
+	if fnType.NumIn() == 0 || fnType.NumOut() == 0 || fnType.NumOut() > 2 ||
+		fnType.In(0) != fnType.Out(0) ||
+		(fnType.NumOut() == 2 && fnType.Out(1) != errorType) {
+		return fmt.Errorf("decorator %v must take the decorated type as its first parameter and return it as (T) or (T, error)", fnType)
+	}
+
+	t := fnType.Out(0)
+	params := make([]param, fnType.NumIn()-1)
+	for i := range params {
+		p, err := newParam(fnType.In(i+1), "")
+		if err != nil {
+			return fmt.Errorf("decorator %v: %w", fnType, err)
+		}
+		params[i] = p
+	}
+
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+
+	dc.decorators[t] = append(dc.decorators[t], &decorator{
+		fn:     reflect.ValueOf(fn),
+		fnType: fnType,
+		params: params,
+		module: module,
+	})
+
+	// Instances built before the decorator was added are stale
+	dc.forgetFailures()
+	dc.evictInstances(t)
+
+	return nil
+}
+
+// decoratedRegistration returns a registration that builds t and runs it through t's decorators,
+// or false if t has no decorators or nothing to decorate. Interfaces are decorated as a node of
+// their own that resolves the bound concrete type. Callers must hold dc.mu (read).
+func (dc *DependencyContainer) decoratedRegistration(t reflect.Type) (*Registration, bool) {
+	decorators := dc.decorators[t]
+	if len(decorators) == 0 {
+		return nil, false
+	}
+
+	base, exists := dc.constructors[t]
+	concreteType, bound := dc.interfaceBindings[t]
+	if !exists && bound {
+		concrete, ok := dc.decoratedRegistration(concreteType)
+		if !ok {
+			if concrete, ok = dc.constructors[concreteType]; !ok {
+				return nil, false
+			}
+		}
+		concreteKey := nodeKey{t: concreteType}
+		base = &Registration{
+			constructor: func(ctx context.Context, container *DependencyContainer, scopeID string, stack []nodeKey) (interface{}, error) {
+				return container.resolveKeyWithStack(ctx, concreteKey, scopeID, stack)
+			},
+			scope:      concrete.scope,
+			paramTypes: []reflect.Type{concreteType},
+			params:     []param{{t: concreteType, dep: dependency{key: concreteKey}}},
+			module:     dc.bindingModules[bindingRef{iface: t}],
+		}
+	} else if !exists {
+		// Types registered by an ancestor are decorated in this container only
+		ancestor := dc.ancestor(nodeKey{t: t})
+		if ancestor == nil {
+			return nil, false
+		}
+		ancestor.mu.RLock()
+		_, _, inherited, ok := ancestor.lookupOwner(nodeKey{t: t})
+		ancestor.mu.RUnlock()
+		if !ok {
+			return nil, false
+		}
+		base = &Registration{
+			constructor: func(ctx context.Context, container *DependencyContainer, scopeID string, stack []nodeKey) (interface{}, error) {
+				// The stack ends with the decorated node itself, which the ancestor resolves afresh
+				return ancestor.resolveWithScope(ctx, t, scopeID, stack[:len(stack)-1])
+			},
+			scope: inherited.scope,
+		}
+	}
+
+	paramTypes := append([]reflect.Type(nil), base.paramTypes...)
+	params := append([]param(nil), base.params...)
+	for _, d := range decorators {
+		for _, p := range d.params {
+			paramTypes = append(paramTypes, p.t)
+			params = append(params, p)
+		}
+	}
+
+	return &Registration{
+		constructor: func(ctx context.Context, container *DependencyContainer, scopeID string, stack []nodeKey) (interface{}, error) {
+			instance, err := base.constructor(ctx, container, scopeID, stack)
+			if err != nil {
+				return nil, err
+			}
+			for _, d := range decorators {
+				if instance, err = d.apply(ctx, container, instance, scopeID, stack); err != nil {
+					return nil, err
+				}
+			}
+			return instance, nil
+		},
+		scope:      base.scope,
+		paramTypes: paramTypes,
+		params:     params,
+		module:     base.module,
+		source:     base.source,
+		caller:     base.caller,
+	}, true
+}
+
+// apply calls the decorator with the instance and its resolved dependencies
+func (d *decorator) apply(ctx context.Context, container *DependencyContainer, instance interface{}, scopeID string, stack []nodeKey) (interface{}, error) {
+	call := &constructorCall{ctx: ctx, stack: stack}
+	defer call.done.Store(true)
+
+	args := make([]reflect.Value, len(d.params)+1)
+	args[0] = reflect.New(d.fnType.In(0)).Elem()
+	if instance != nil {
+		args[0].Set(reflect.ValueOf(instance))
+	}
+	for i, p := range d.params {
+		arg, err := container.buildParam(p, scopeID, call)
+		if err != nil {
+			return nil, fmt.Errorf("error resolving dependency %v (parameter %d of decorator %v): %w", p, i+2, d.fnType, err)
+		}
+		args[i+1] = arg
+	}
+
+	results, err := container.call(d.fn, args, d.module, stack)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) == 2 && !results[1].IsNil() {
+		return nil, &ConstructorError{
+			Type:      d.fnType.Out(0),
+			Func:      funcName(d.fn),
+			Location:  funcFile(d.fn),
+			Module:    d.module,
+			Path:      stackPath(stack),
+			Locations: container.locations(stack),
+			Cause:     results[1].Interface().(error),
+		}
+	}
+	return results[0].Interface(), nil
+}
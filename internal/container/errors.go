@@ -0,0 +1,273 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// MissingDependencyError reports a dependency that nothing is registered for
+type MissingDependencyError struct {
+	Type        reflect.Type      // Missing type
+	Name        string            // Name qualifier ("" if unnamed)
+	RequestedBy []string          // Nodes that need it (every consumer when reported by Validate)
+	Path        []string          // Resolution chain that led to it (empty when reported by Validate)
+	Locations   map[string]string // Where the nodes in RequestedBy and Path are registered
+}
+
+func (e *MissingDependencyError) Error() string {
+	var msg string
+	switch {
+	case e.Name != "" && e.Type.Kind() == reflect.Interface:
+		msg = fmt.Sprintf("no binding found for interface %v with name %q", e.Type, e.Name)
+	case e.Name != "":
+		msg = fmt.Sprintf("no constructor found for named dependency %v (%s)", e.Type, e.Name)
+	case e.Type.Kind() == reflect.Interface:
+		msg = fmt.Sprintf("no binding found for interface %v", e.Type)
+	default:
+		msg = fmt.Sprintf("no constructor registered for type %v", e.Type)
+	}
+	return msg + requiredBy(e.RequestedBy) + resolutionPath(e.Path) + where(append(append([]string{}, e.RequestedBy...), e.Path...), e.Locations)
+}
+
+// node returns the graph node ID of the missing dependency
+func (e *MissingDependencyError) node() string {
+	return nodeKey{t: e.Type, name: e.Name}.String()
+}
+
+// CycleError reports a circular dependency. Path starts and ends with the same node, e.g. [*A *B *A].
+type CycleError struct {
+	Path      []string
+	Locations map[string]string // Where the nodes in Path are registered
+}
+
+func (e *CycleError) Error() string {
+	msg := "circular dependency detected: " + strings.Join(e.Path, " -> ")
+	if len(e.Path) > 0 {
+		msg += where(e.Path[:len(e.Path)-1], e.Locations)
+	}
+	return msg
+}
+
+// ScopeNotFoundError reports a scoped dependency resolved in a scope that has been destroyed while
+// instances for it were still being built. An instance whose construction outlived its scope is
+// closed instead of being cached.
+type ScopeNotFoundError struct {
+	ScopeID string
+	Type    reflect.Type
+}
+
+func (e *ScopeNotFoundError) Error() string {
+	return fmt.Sprintf("scope %s does not exist or has been destroyed (resolving %v)", e.ScopeID, e.Type)
+}
+
+// ConstructorError reports a constructor (or decorator) that returned an error
+type ConstructorError struct {
+	Type      reflect.Type      // Type being built
+	Func      string            // Name of the function that failed
+	Location  string            // file:line of the function
+	Module    string            // Module that registered the function ("" if none)
+	Path      []string          // Resolution chain, ending with the node being built
+	Locations map[string]string // Where the nodes in Path are registered
+	Cause     error
+}
+
+func (e *ConstructorError) Error() string {
+	fn := e.Func
+	if e.Location != "" {
+		fn += " (" + e.Location + ")"
+	}
+	msg := fmt.Sprintf("constructor %s for %v failed: %v", fn, e.Type, e.Cause)
+	if e.Module != "" {
+		msg = fmt.Sprintf("constructor %s for %v (module %s) failed: %v", fn, e.Type, e.Module, e.Cause)
+	}
+	return msg + resolutionPath(e.Path) + where(e.Path, e.Locations)
+}
+
+func (e *ConstructorError) Unwrap() error {
+	return e.Cause
+}
+
+// DuplicateRegistrationError reports a second registration for a type (and name) that is
+// already registered. Use Override, or the Replace registration option, to replace it on purpose.
+type DuplicateRegistrationError struct {
+	Type      reflect.Type
+	Name      string // Name qualifier ("" if unnamed)
+	Existing  string // Registration already in place, e.g. "main.NewClient (client.go:12)"
+	Duplicate string // Registration that was rejected
+}
+
+func (e *DuplicateRegistrationError) Error() string {
+	return fmt.Sprintf("duplicate registration for %s: %s is already registered, rejecting %s (use Override to replace it)",
+		nodeKey{t: e.Type, name: e.Name}, e.Existing, e.Duplicate)
+}
+
+// ScopeRequiredError reports a scoped dependency resolved without a scope ID
+type ScopeRequiredError struct {
+	Type  reflect.Type
+	Name  string // Name qualifier ("" if unnamed)
+	Group string // Value group ("" if not a group member)
+}
+
+func (e *ScopeRequiredError) Error() string {
+	switch {
+	case e.Group != "":
+		return fmt.Sprintf("scope ID required for scoped group member %v of value group %q", e.Type, e.Group)
+	case e.Name != "":
+		return fmt.Sprintf("scope ID required for named scoped dependency %v (%s)", e.Type, e.Name)
+	default:
+		return fmt.Sprintf("scope ID required for scoped dependency %v", e.Type)
+	}
+}
+
+func requiredBy(consumers []string) string {
+	if len(consumers) == 0 {
+		return ""
+	}
+	return " (required by " + strings.Join(consumers, ", ") + ")"
+}
+
+// resolutionPath renders a resolution chain. Chains of a single node add nothing to the message.
+func resolutionPath(path []string) string {
+	if len(path) < 2 {
+		return ""
+	}
+	return "; resolution path: " + strings.Join(path, " -> ")
+}
+
+// where renders where each of the given nodes is registered, e.g.
+// "; where *main.Server is main.NewServer (main.go:12), registered at main.go:40"
+func where(nodes []string, locations map[string]string) string {
+	var parts []string
+	seen := make(map[string]bool)
+	for _, node := range nodes {
+		if location := locations[node]; location != "" && !seen[node] {
+			seen[node] = true
+			parts = append(parts, node+" is "+location)
+		}
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "; where " + strings.Join(parts, "; ")
+}
+
+// funcName returns the name of a function value, e.g. "main.NewServer"
+func funcName(fn reflect.Value) string {
+	if f := runtime.FuncForPC(fn.Pointer()); f != nil {
+		return f.Name()
+	}
+	return fn.Type().String()
+}
+
+// funcLocation returns the name and source position of a function value, e.g. "main.NewServer (main.go:12)"
+func funcLocation(fn reflect.Value) string {
+	if file := funcFile(fn); file != "" {
+		return funcName(fn) + " (" + file + ")"
+	}
+	return funcName(fn)
+}
+
+// sameFunc reports whether two function values are the same value: the same code (Pointer) and
+// the same closure record (see closureRecord). Closures made from one literal share their code,
+// but each evaluation of a literal that captures variables gets its own record.
+func sameFunc(a, b reflect.Value) bool {
+	if !a.IsValid() || !b.IsValid() || a.Kind() != reflect.Func || b.Kind() != reflect.Func {
+		return false
+	}
+	return a.Pointer() == b.Pointer() && closureRecord(a) == closureRecord(b)
+}
+
+// closureRecord returns the address a func value points to. A func value is a pointer to a closure
+// record holding the code pointer followed by the captured variables. Top-level functions and
+// literals that capture nothing use one static record. The registrations keep the values alive,
+// so a record cannot be reused while they are compared.
+func closureRecord(fn reflect.Value) uintptr {
+	if fn.IsNil() {
+		return 0
+	}
+	v := reflect.New(fn.Type())
+	v.Elem().Set(fn)
+	return *(*uintptr)(v.UnsafePointer())
+}
+
+// funcFile returns the file:line where a function value is defined ("" if unknown)
+func funcFile(fn reflect.Value) string {
+	f := runtime.FuncForPC(fn.Pointer())
+	if f == nil {
+		return ""
+	}
+	file, line := f.FileLine(fn.Pointer())
+	return fmt.Sprintf("%s:%d", filepath.Base(file), line)
+}
+
+// libraryPrefix is the import path prefix shared by this module's packages
+var libraryPrefix = strings.TrimSuffix(reflect.TypeOf(DependencyContainer{}).PkgPath(), "internal/container")
+
+// CallerLocation returns the file:line of the first caller outside this module's
+// internal and pkg packages, i.e. the application code that made a registration
+func CallerLocation() string {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, libraryPrefix+"internal/") && !strings.HasPrefix(frame.Function, libraryPrefix+"pkg/") {
+			return fmt.Sprintf("%s:%d", filepath.Base(frame.File), frame.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+// stackPath renders a resolution stack for error values
+func stackPath(stack []nodeKey) []string {
+	path := make([]string, len(stack))
+	for i, key := range stack {
+		path[i] = key.String()
+	}
+	return path
+}
+
+// contextError wraps the error of a done context with the resolution path it interrupted
+func contextError(ctx context.Context, stack []nodeKey) error {
+	return fmt.Errorf("resolution of %s aborted: %w", strings.Join(stackPath(stack), " -> "), ctx.Err())
+}
+
+// missingError builds the error for a dependency that could not be found while resolving stack
+func (dc *DependencyContainer) missingError(t reflect.Type, name string, stack []nodeKey) error {
+	err := &MissingDependencyError{Type: t, Name: name, Path: stackPath(stack), Locations: dc.locations(stack)}
+	if len(stack) > 0 {
+		err.RequestedBy = []string{stack[len(stack)-1].String()}
+	}
+	return err
+}
+
+// checkCycle returns a *CycleError if key is already being resolved in the current call stack
+func (dc *DependencyContainer) checkCycle(key nodeKey, stack []nodeKey) error {
+	for _, stackKey := range stack {
+		if stackKey == key {
+			return &CycleError{Path: append(stackPath(stack), key.String()), Locations: dc.locations(stack)}
+		}
+	}
+	return nil
+}
+
+// locations describes where each node of a resolution stack is registered
+func (dc *DependencyContainer) locations(stack []nodeKey) map[string]string {
+	dc.mu.RLock()
+	defer dc.mu.RUnlock()
+
+	locations := make(map[string]string, len(stack))
+	for _, key := range stack {
+		if _, _, reg, ok := dc.lookupOwner(key); ok {
+			if location := reg.describe(); location != "" {
+				locations[key.String()] = location
+			}
+		}
+	}
+	return locations
+}
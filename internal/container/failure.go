@@ -0,0 +1,73 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// FailureMode decides what happens after building a singleton or scoped instance fails
+type FailureMode int
+
+const (
+	RetryOnNextCall  FailureMode = iota // Share the error with concurrent callers only; the next resolve builds again
+	CacheFailure                        // Cache the error: later resolves return it without building again
+	RetryWithBackoff                    // Retry the constructor with exponential backoff before giving up
+)
+
+// FailurePolicy configures how failed builds are handled. Whatever the policy, callers waiting
+// on the same instance share the outcome of a single build instead of building it themselves.
+type FailurePolicy struct {
+	Mode     FailureMode
+	Attempts int           // Total attempts with RetryWithBackoff (including the first)
+	Backoff  time.Duration // Delay before the first retry, doubled after each attempt
+}
+
+// SetFailurePolicy sets how failed builds of singleton and scoped instances are handled.
+// Registering, overriding or supplying anything forgets cached failures.
+func (dc *DependencyContainer) SetFailurePolicy(policy FailurePolicy) {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+	dc.failurePolicy = policy
+	dc.forgetFailures()
+}
+
+// attempt runs construct for the node on top of stack, retrying failures of its own constructor
+// (or decorators) as the policy allows. Failed dependencies are retried by their own builds.
+func (dc *DependencyContainer) attempt(ctx context.Context, stack []nodeKey, construct func() (interface{}, error)) (interface{}, error) {
+	dc.mu.RLock()
+	policy := dc.failurePolicy
+	dc.mu.RUnlock()
+
+	delay := policy.Backoff
+	for attempt := 1; ; attempt++ {
+		instance, err := construct()
+		if err == nil || policy.Mode != RetryWithBackoff || attempt >= policy.Attempts || !failedItself(err, stack) {
+			return instance, err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, contextError(ctx, stack)
+		}
+		delay *= 2
+	}
+}
+
+// failedItself reports whether err was returned or raised by the constructor of the node on top of stack,
+// rather than by one of its dependencies
+func failedItself(err error, stack []nodeKey) bool {
+	var path []string
+	var ctorErr *ConstructorError
+	var panicErr *ConstructorPanicError
+	switch {
+	case errors.As(err, &ctorErr):
+		path = ctorErr.Path
+	case errors.As(err, &panicErr):
+		path = panicErr.Path
+	}
+	return len(path) > 0 && len(stack) > 0 && path[len(path)-1] == stack[len(stack)-1].String()
+}
@@ -0,0 +1,253 @@
+package container
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Graph is a snapshot of the dependency graph: every registration, every interface or named
+// qualifier it is reached through, and the edges between them
+type Graph struct {
+	Nodes []GraphNode `json:"nodes"`
+	Edges []GraphEdge `json:"edges"`
+}
+
+// GraphNode is one node of the dependency graph
+type GraphNode struct {
+	ID           string `json:"id"`               // Unique node identifier, e.g. "[primary]*sql.DB"
+	Type         string `json:"type"`             // Go type of the node
+	Name         string `json:"name,omitempty"`   // Name qualifier
+	Group        string `json:"group,omitempty"`  // Value group the node belongs to (or is)
+	Module       string `json:"module,omitempty"` // Module that registered the node
+	Scope        string `json:"scope,omitempty"`  // Lifetime of the registration (empty for bindings and missing nodes)
+	Interface    bool   `json:"interface,omitempty"`
+	Supplied     bool   `json:"supplied,omitempty"`
+	Decorated    bool   `json:"decorated,omitempty"`
+	Inherited    bool   `json:"inherited,omitempty"` // Served by a parent container (its dependencies are in the parent's graph)
+	Missing      bool   `json:"missing,omitempty"`   // Nothing is registered to serve the node
+	Instantiated bool   `json:"instantiated"`        // A singleton instance is already cached
+}
+
+// EdgeKind describes how one node reaches another
+type EdgeKind string
+
+const (
+	EdgeDependency EdgeKind = "dependency" // Constructor parameter
+	EdgeOptional   EdgeKind = "optional"   // Parameter injected only if registered
+	EdgeLazy       EdgeKind = "lazy"       // Provider or func parameter resolved on demand
+	EdgeBinding    EdgeKind = "binding"    // Interface binding or fallback to the unnamed registration
+)
+
+// GraphEdge points from a node to a node it needs
+type GraphEdge struct {
+	From string   `json:"from"`
+	To   string   `json:"to"`
+	Kind EdgeKind `json:"kind"`
+}
+
+// Graph builds a snapshot of the dependency graph in a deterministic order
+func (dc *DependencyContainer) Graph() *Graph {
+	dc.mu.RLock()
+	defer dc.mu.RUnlock()
+
+	var roots []nodeKey
+	for t := range dc.constructors {
+		roots = append(roots, nodeKey{t: t})
+	}
+	for name, nameMap := range dc.namedConstructors {
+		for t := range nameMap {
+			roots = append(roots, nodeKey{t: t, name: name})
+		}
+	}
+	for t := range dc.interfaceBindings {
+		roots = append(roots, nodeKey{t: t})
+	}
+	for name, bindings := range dc.namedInterfaceBindings {
+		for t := range bindings {
+			roots = append(roots, nodeKey{t: t, name: name})
+		}
+	}
+	for group, members := range dc.groups {
+		for i := range members {
+			roots = append(roots, dc.groupMemberKey(group, i))
+		}
+	}
+	for t := range dc.decorators {
+		roots = append(roots, nodeKey{t: t})
+	}
+
+	g := &Graph{}
+	visited := make(map[nodeKey]bool)
+	var visit func(key nodeKey)
+	visit = func(key nodeKey) {
+		if visited[key] {
+			return
+		}
+		visited[key] = true
+
+		node := GraphNode{
+			ID:        key.String(),
+			Type:      key.t.String(),
+			Name:      key.name,
+			Group:     key.group,
+			Interface: key.t.Kind() == reflect.Interface,
+		}
+
+		_, resolved, reg, ok := dc.lookupOwner(key)
+		switch {
+		case !ok:
+			node.Missing = true
+		case dc.delegate(key) != nil:
+			node.Inherited = true
+			node.Scope = reg.scope.String()
+			node.Module = reg.module
+		case resolved != key:
+			// Served by another node through a binding or the unnamed fallback
+			node.Module = dc.bindingModules[bindingRef{name: key.name, iface: key.t}]
+			g.Edges = append(g.Edges, GraphEdge{From: node.ID, To: resolved.String(), Kind: EdgeBinding})
+			visit(resolved)
+		default:
+			node.Scope = reg.scope.String()
+			node.Module = reg.module
+			node.Supplied = reg.supplied || reg.scopedValue
+			node.Decorated = key.name == "" && key.group == "" && len(dc.decorators[key.t]) > 0
+			if reg.supplied {
+				node.Instantiated = true
+			} else if reg.scope == Singleton {
+				cacheKey := instanceKey{name: key.name, group: key.group, t: key.t}
+				if member, ok := dc.localGroupMember(key); ok {
+					cacheKey.member = member.id
+				}
+				_, node.Instantiated = dc.lookupInstance(cacheKey)
+			}
+			for _, dep := range dc.servedDependencies(reg) {
+				kind := EdgeDependency
+				switch {
+				case dep.lazy != nil:
+					kind = EdgeLazy
+				case dep.optional:
+					kind = EdgeOptional
+				}
+				g.Edges = append(g.Edges, GraphEdge{From: node.ID, To: dep.key.String(), Kind: kind})
+				visit(dep.key)
+			}
+		}
+		g.Nodes = append(g.Nodes, node)
+	}
+	for _, key := range roots {
+		visit(key)
+	}
+
+	sort.Slice(g.Nodes, func(i, j int) bool { return g.Nodes[i].ID < g.Nodes[j].ID })
+	sort.SliceStable(g.Edges, func(i, j int) bool {
+		if g.Edges[i].From != g.Edges[j].From {
+			return g.Edges[i].From < g.Edges[j].From
+		}
+		return g.Edges[i].To < g.Edges[j].To
+	})
+	return g
+}
+
+// label describes a node for the diagram exporters
+func (n GraphNode) label() string {
+	var details []string
+	switch {
+	case n.Missing:
+		details = append(details, "missing")
+	case n.Scope != "":
+		details = append(details, n.Scope)
+	case n.Interface:
+		details = append(details, "interface")
+	}
+	if n.Supplied {
+		details = append(details, "supplied")
+	}
+	if n.Decorated {
+		details = append(details, "decorated")
+	}
+	if n.Module != "" {
+		details = append(details, "module "+n.Module)
+	}
+	if n.Inherited {
+		details = append(details, "inherited")
+	}
+	if n.Instantiated && !n.Supplied {
+		details = append(details, "instantiated")
+	}
+	return n.ID + "\n" + strings.Join(details, ", ")
+}
+
+// DOT renders the graph in Graphviz DOT format
+func (g *Graph) DOT() string {
+	var b strings.Builder
+	b.WriteString("digraph dependencies {\n\trankdir=LR;\n\tnode [shape=box];\n")
+	for _, n := range g.Nodes {
+		attrs := "label=" + dotQuote(n.label())
+		switch {
+		case n.Missing:
+			attrs += ", color=red, style=dashed"
+		case n.Interface && n.Scope == "":
+			attrs += ", shape=ellipse"
+		case n.Instantiated:
+			attrs += ", style=filled, fillcolor=lightgrey"
+		}
+		fmt.Fprintf(&b, "\t%s [%s];\n", dotQuote(n.ID), attrs)
+	}
+	for _, e := range g.Edges {
+		style := ""
+		switch e.Kind {
+		case EdgeLazy, EdgeOptional:
+			style = " [style=dashed, label=" + dotQuote(string(e.Kind)) + "]"
+		case EdgeBinding:
+			style = " [style=bold]"
+		}
+		fmt.Fprintf(&b, "\t%s -> %s%s;\n", dotQuote(e.From), dotQuote(e.To), style)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// dotQuote renders s as a DOT quoted string. Unlike %q it never emits Go
+// escapes such as \u00a0, which Graphviz does not understand; it only escapes
+// quotes and backslashes and keeps newlines as the \n line break.
+func dotQuote(s string) string {
+	return `"` + dotEscaper.Replace(s) + `"`
+}
+
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// Mermaid renders the graph as a Mermaid flowchart
+func (g *Graph) Mermaid() string {
+	ids := make(map[string]string, len(g.Nodes))
+	var b strings.Builder
+	b.WriteString("graph LR\n")
+	for i, n := range g.Nodes {
+		ids[n.ID] = fmt.Sprintf("n%d", i)
+		label := strings.ReplaceAll(n.label(), `"`, "#quot;")
+		label = strings.ReplaceAll(label, "\n", "<br/>")
+		shape := `["%s"]`
+		if n.Interface && n.Scope == "" {
+			shape = `(["%s"])`
+		}
+		fmt.Fprintf(&b, "    %s"+shape+"\n", ids[n.ID], label)
+	}
+	for _, e := range g.Edges {
+		arrow := "-->"
+		switch e.Kind {
+		case EdgeLazy, EdgeOptional:
+			arrow = "-. " + string(e.Kind) + " .->"
+		case EdgeBinding:
+			arrow = "==>"
+		}
+		fmt.Fprintf(&b, "    %s %s %s\n", ids[e.From], arrow, ids[e.To])
+	}
+	return b.String()
+}
+
+// JSON renders the graph as indented JSON
+func (g *Graph) JSON() ([]byte, error) {
+	return json.MarshalIndent(g, "", "  ")
+}
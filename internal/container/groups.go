@@ -0,0 +1,140 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+)
+
+// groupMember is one constructor contributing to a value group. Its instances are cached by id:
+// a member's index shifts when an ancestor container adds members to the group, its id does not.
+type groupMember struct {
+	id  uint64
+	t   reflect.Type
+	reg *Registration
+}
+
+// groupMemberIDs numbers group members across all containers
+var groupMemberIDs atomic.Uint64
+
+// ResolveGroup resolves all members of a value group as a slice of type t (e.g. []http.Handler)
+func (dc *DependencyContainer) ResolveGroup(group string, t reflect.Type, scopeID string) (interface{}, error) {
+	if t.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("value group %q must be resolved as a slice, got %v", group, t)
+	}
+	return dc.resolveKeyWithStack(context.Background(), nodeKey{t: t, group: group}, scopeID, nil)
+}
+
+// addGroupMember appends a registration to a value group. Callers must hold dc.mu (write).
+func (dc *DependencyContainer) addGroupMember(group string, t reflect.Type, registration *Registration) {
+	dc.groups[group] = append(dc.groups[group], groupMember{id: groupMemberIDs.Add(1), t: t, reg: registration})
+	dc.forgetFailures()
+}
+
+// localGroupMember returns the member a group member key refers to if dc registers it.
+// Callers must hold dc.mu (read).
+func (dc *DependencyContainer) localGroupMember(key nodeKey) (groupMember, bool) {
+	if key.group == "" || key.index == 0 {
+		return groupMember{}, false
+	}
+	members := dc.groups[key.group]
+	i := key.index - dc.inheritedMemberCount(key.group) - 1
+	if i < 0 || i >= len(members) {
+		return groupMember{}, false
+	}
+	return members[i], true
+}
+
+// groupRegistration builds a synthetic registration that assembles every member of a group
+// into a slice of key.t, so groups are resolved and validated like any other node.
+// Callers must hold dc.mu (read).
+func (dc *DependencyContainer) groupRegistration(key nodeKey) (*Registration, error) {
+	if key.t.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("value group %q must be injected as a slice, got %v", key.group, key.t)
+	}
+
+	elemType := key.t.Elem()
+	memberTypes := dc.inheritedMemberTypes(key.group)
+	for _, m := range dc.groups[key.group] {
+		memberTypes = append(memberTypes, m.t)
+	}
+	paramTypes := make([]reflect.Type, len(memberTypes))
+	params := make([]param, len(memberTypes))
+	for i, t := range memberTypes {
+		if !t.AssignableTo(elemType) {
+			return nil, fmt.Errorf("member %v of value group %q is not assignable to %v", t, key.group, elemType)
+		}
+		memberKey := nodeKey{t: t, group: key.group, index: i + 1}
+		paramTypes[i] = t
+		params[i] = param{t: t, dep: dependency{key: memberKey}}
+	}
+
+	return &Registration{
+		constructor: func(ctx context.Context, container *DependencyContainer, scopeID string, stack []nodeKey) (interface{}, error) {
+			slice := reflect.MakeSlice(key.t, 0, len(params))
+			call := &constructorCall{ctx: ctx, stack: stack}
+			for _, p := range params {
+				value, err := container.buildParam(p, scopeID, call)
+				if err != nil {
+					return nil, fmt.Errorf("error resolving member %v of value group %q: %w", p, key.group, err)
+				}
+				slice = reflect.Append(slice, value)
+			}
+			return slice.Interface(), nil
+		},
+		scope:      Transient,
+		paramTypes: paramTypes,
+		params:     params,
+	}, nil
+}
+
+// resolveGroupKey resolves a whole group or a single group member
+func (dc *DependencyContainer) resolveGroupKey(ctx context.Context, key nodeKey, scopeID string, stack []nodeKey) (interface{}, error) {
+	if err := dc.checkCycle(key, stack); err != nil {
+		return nil, err
+	}
+	newStack := append(stack, key)
+
+	dc.mu.RLock()
+	owner := dc.delegate(key)
+	var registration *Registration
+	var err error
+	cacheKey := instanceKey{group: key.group, t: key.t}
+	if owner != nil {
+		// Inherited members are built and cached by the ancestor that registered them
+	} else if key.index == 0 {
+		registration, err = dc.groupRegistration(key)
+	} else if member, ok := dc.localGroupMember(key); ok {
+		registration = member.reg
+		cacheKey.member = member.id
+	} else {
+		err = fmt.Errorf("no member %d in value group %q", key.index, key.group)
+	}
+	dc.mu.RUnlock()
+	if owner != nil {
+		return owner.resolveGroupKey(ctx, key, scopeID, stack)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch registration.scope {
+	case Singleton:
+		return dc.resolveCached(ctx, cacheKey, newStack, func() (interface{}, error) {
+			return registration.constructor(ctx, dc, scopeID, newStack)
+		})
+	case Transient:
+		return registration.constructor(ctx, dc, scopeID, newStack)
+	case Scoped:
+		if scopeID == "" {
+			return nil, &ScopeRequiredError{Type: key.t, Group: key.group}
+		}
+		cacheKey.scopeID = scopeID
+		return dc.resolveCached(ctx, cacheKey, newStack, func() (interface{}, error) {
+			return registration.constructor(ctx, dc, scopeID, newStack)
+		})
+	default:
+		return nil, fmt.Errorf("unknown scope type for %v", key)
+	}
+}
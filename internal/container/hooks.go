@@ -0,0 +1,162 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Starter is implemented by long-running components that must be started after the graph is built
+type Starter interface {
+	Start(ctx context.Context) error
+}
+
+// Stopper is implemented by long-running components that must be stopped gracefully
+type Stopper interface {
+	Stop(ctx context.Context) error
+}
+
+// SetHookTimeout sets the maximum duration of each individual Start/Stop hook (0 disables the limit)
+func (dc *DependencyContainer) SetHookTimeout(timeout time.Duration) {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+	dc.hookTimeout = timeout
+}
+
+// Start instantiates every singleton in dependency order and calls Start on those implementing Starter.
+// Supplied values are not started: the container does not own them.
+// If a hook fails, the components already started are stopped in reverse order and the errors are joined.
+func (dc *DependencyContainer) Start(ctx context.Context) error {
+	dc.mu.Lock()
+	if dc.running != nil || dc.starting {
+		dc.mu.Unlock()
+		return fmt.Errorf("container already started")
+	}
+	dc.starting = true
+	order := dc.startupOrder()
+	dc.mu.Unlock()
+
+	running := make([]interface{}, 0, len(order))
+	defer func() {
+		dc.mu.Lock()
+		dc.starting = false
+		dc.mu.Unlock()
+	}()
+
+	// Rolling back must not be cut short by the context that made the start fail
+	rollback := func(err error) error {
+		return errors.Join(err, dc.stopAll(context.WithoutCancel(ctx), running))
+	}
+	for _, key := range order {
+		instance, err := dc.resolveKeyWithStack(ctx, key, "", nil)
+		if err != nil {
+			return rollback(fmt.Errorf("failed to start %v: %w", key.t, err))
+		}
+
+		if starter, ok := instance.(Starter); ok {
+			if err := dc.runHook(ctx, starter.Start); err != nil {
+				return rollback(fmt.Errorf("failed to start %T: %w", instance, err))
+			}
+		}
+		running = append(running, instance)
+	}
+
+	dc.mu.Lock()
+	dc.running = running
+	dc.mu.Unlock()
+	return nil
+}
+
+// Stop calls Stop on every started component implementing Stopper, in reverse startup order
+func (dc *DependencyContainer) Stop(ctx context.Context) error {
+	dc.mu.Lock()
+	running := dc.running
+	dc.running = nil
+	dc.mu.Unlock()
+
+	return dc.stopAll(ctx, running)
+}
+
+func (dc *DependencyContainer) stopAll(ctx context.Context, running []interface{}) error {
+	var errs []error
+	for i := len(running) - 1; i >= 0; i-- {
+		stopper, ok := running[i].(Stopper)
+		if !ok {
+			continue
+		}
+		if err := dc.runHook(ctx, stopper.Stop); err != nil {
+			errs = append(errs, fmt.Errorf("failed to stop %T: %w", running[i], err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// runHook invokes a lifecycle hook bounded by the configured per-hook timeout
+func (dc *DependencyContainer) runHook(ctx context.Context, hook func(context.Context) error) error {
+	dc.mu.RLock()
+	timeout := dc.hookTimeout
+	dc.mu.RUnlock()
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return hook(ctx)
+}
+
+// startupOrder returns all singleton registrations in topological order (dependencies first),
+// derived from each registration's parameter types. Supplied values are left out.
+// Callers must hold dc.mu (read).
+func (dc *DependencyContainer) startupOrder() []nodeKey {
+	var keys []nodeKey
+	for t, reg := range dc.constructors {
+		if reg.scope == Singleton && !reg.supplied {
+			keys = append(keys, nodeKey{t: t})
+		}
+	}
+	for name, nameMap := range dc.namedConstructors {
+		for t, reg := range nameMap {
+			if reg.scope == Singleton && !reg.supplied {
+				keys = append(keys, nodeKey{t: t, name: name})
+			}
+		}
+	}
+	for group, members := range dc.groups {
+		for i, m := range members {
+			if m.reg.scope == Singleton {
+				keys = append(keys, dc.groupMemberKey(group, i))
+			}
+		}
+	}
+	// Map iteration is random; sort for a deterministic order among independent components
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].String() < keys[j].String()
+	})
+
+	visited := make(map[nodeKey]bool)
+	var order []nodeKey
+	var visit func(key nodeKey)
+	visit = func(key nodeKey) {
+		key, reg, ok := dc.lookupRegistration(key)
+		if !ok || visited[key] {
+			return
+		}
+		visited[key] = true
+		for _, dep := range dc.servedDependencies(reg) {
+			// Lazy dependencies are not needed to build the component
+			if dep.lazy == nil {
+				visit(dep.key)
+			}
+		}
+		if reg.scope == Singleton && !reg.supplied {
+			order = append(order, key)
+		}
+	}
+	for _, key := range keys {
+		visit(key)
+	}
+	return order
+}
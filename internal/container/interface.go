@@ -7,31 +7,17 @@ import (
 
 // BindInterface binds an interface type to a concrete implementation
 func (dc *DependencyContainer) BindInterface(interfaceType, concreteType reflect.Type) error {
-	dc.mu.Lock()
-	defer dc.mu.Unlock()
-
-	// Validate that interfaceType is actually an interface
-	if interfaceType.Kind() != reflect.Interface {
-		return fmt.Errorf("type %v is not an interface", interfaceType)
-	}
-
-	// Validate that concreteType implements interfaceType
-	if !concreteType.Implements(interfaceType) {
-		return fmt.Errorf("type %v does not implement interface %v", concreteType, interfaceType)
-	}
-
-	// Check if concrete type has a constructor registered
-	if _, exists := dc.constructors[concreteType]; !exists {
-		return fmt.Errorf("no constructor registered for concrete type %v. Register the constructor first before binding the interface", concreteType)
-	}
-
-	// Store the binding
-	dc.interfaceBindings[interfaceType] = concreteType
-	return nil
+	return dc.BindModuleInterface("", "", interfaceType, concreteType)
 }
 
 // BindInterfaceNamed binds an interface type to a concrete implementation with a name
 func (dc *DependencyContainer) BindInterfaceNamed(name string, interfaceType, concreteType reflect.Type) error {
+	return dc.BindModuleInterface("", name, interfaceType, concreteType)
+}
+
+// BindModuleInterface binds an interface type to a concrete implementation, with a name if name is
+// not empty, on behalf of a module. Validation reports and graph exports name the module.
+func (dc *DependencyContainer) BindModuleInterface(module, name string, interfaceType, concreteType reflect.Type) error {
 	dc.mu.Lock()
 	defer dc.mu.Unlock()
 
@@ -45,18 +31,27 @@ func (dc *DependencyContainer) BindInterfaceNamed(name string, interfaceType, co
 		return fmt.Errorf("type %v does not implement interface %v", concreteType, interfaceType)
 	}
 
-	// Check if concrete type has a constructor registered
-	if _, exists := dc.constructors[concreteType]; !exists {
+	// Check if concrete type has a constructor registered (here or in an ancestor)
+	if _, exists := dc.constructors[concreteType]; !exists && dc.ancestor(nodeKey{t: concreteType}) == nil {
 		return fmt.Errorf("no constructor registered for concrete type %v. Register the constructor first before binding the interface", concreteType)
 	}
 
-	// Ensure the named bindings map exists for this name
-	if dc.namedInterfaceBindings[name] == nil {
-		dc.namedInterfaceBindings[name] = make(map[reflect.Type]reflect.Type)
+	// Store the binding
+	if name == "" {
+		dc.interfaceBindings[interfaceType] = concreteType
+	} else {
+		if dc.namedInterfaceBindings[name] == nil {
+			dc.namedInterfaceBindings[name] = make(map[reflect.Type]reflect.Type)
+		}
+		dc.namedInterfaceBindings[name][interfaceType] = concreteType
 	}
-
-	// Store the named binding
-	dc.namedInterfaceBindings[name][interfaceType] = concreteType
+	ref := bindingRef{name: name, iface: interfaceType}
+	if module != "" {
+		dc.bindingModules[ref] = module
+	} else {
+		delete(dc.bindingModules, ref)
+	}
+	dc.forgetFailures()
 	return nil
 }
 
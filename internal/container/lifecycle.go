@@ -0,0 +1,75 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// Disposer is implemented by instances that release resources and accept a context.
+// Instances implementing either Disposer or io.Closer are closed by the container.
+type Disposer interface {
+	Close(ctx context.Context) error
+}
+
+// trackDisposable records an instance that must be closed when its owner (the container or a scope)
+// goes away. Only cached instances are tracked; transient instances belong to the caller.
+// Callers must hold dc.mu (write).
+func (dc *DependencyContainer) trackDisposable(key instanceKey, instance interface{}) {
+	if !isDisposable(instance) {
+		return
+	}
+	if key.scopeID == "" {
+		dc.disposables = append(dc.disposables, instance)
+		return
+	}
+	dc.scopedDisposables[key.scopeID] = append(dc.scopedDisposables[key.scopeID], instance)
+}
+
+// Shutdown stops started components, then closes all scoped instances and all singletons in reverse creation order.
+// Eager dependencies finish construction before their dependents, so reverse creation order
+// closes an instance before the instances it depends on. A dependency first resolved through a lazy
+// accessor after its holder was built is newer than the holder and is closed first. Caches are cleared afterwards.
+func (dc *DependencyContainer) Shutdown(ctx context.Context) error {
+	stopErr := dc.Stop(ctx)
+	scopeErr := dc.destroyAllScopes(ctx)
+
+	dc.mu.Lock()
+	disposables := dc.disposables
+	dc.disposables = nil
+	dc.dependencies = make(map[reflect.Type]interface{})
+	dc.namedDependencies = make(map[string]map[reflect.Type]interface{})
+	dc.groupInstances = make(map[instanceKey]interface{})
+	dc.mu.Unlock()
+
+	return errors.Join(stopErr, scopeErr, dispose(ctx, disposables))
+}
+
+func isDisposable(instance interface{}) bool {
+	switch instance.(type) {
+	case Disposer, io.Closer:
+		return true
+	default:
+		return false
+	}
+}
+
+// dispose closes instances in reverse order and aggregates every failure
+func dispose(ctx context.Context, instances []interface{}) error {
+	var errs []error
+	for i := len(instances) - 1; i >= 0; i-- {
+		var err error
+		switch inst := instances[i].(type) {
+		case Disposer:
+			err = inst.Close(ctx)
+		case io.Closer:
+			err = inst.Close()
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to close %T: %w", instances[i], err))
+		}
+	}
+	return errors.Join(errs...)
+}
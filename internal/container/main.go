@@ -1,7 +1,9 @@
 package container
 
 import (
+	"context"
 	"fmt"
+	"maps"
 	"reflect"
 )
 
@@ -19,28 +21,170 @@ func (dc *DependencyContainer) RegisterConstructor(
 func (dc *DependencyContainer) RegisterConstructorWithScope(
 	constructor interface{},
 	scope Scope,
+) error {
+	return dc.RegisterConstructorWithOptions(constructor, scope, RegistrationOptions{})
+}
+
+// RegistrationOptions carries optional metadata for a constructor registration
+type RegistrationOptions struct {
+	Name       string   // Register as a named dependency instead of the unnamed default
+	Group      string   // Add the constructor to a value group instead (resolved as a slice)
+	ParamNames []string // Qualifier for each constructor parameter, by position ("" = unnamed)
+	Module     string   // Module the registration comes from, for diagnostics ("" if none)
+	Replace    bool     // Replace an existing registration of the same type and name instead of failing
+	Caller     string   // file:line of the registering call ("" = the first caller outside this module)
+}
+
+// RegisterConstructorWithOptions adds a constructor function with a specific scope and registration options
+func (dc *DependencyContainer) RegisterConstructorWithOptions(
+	constructor interface{},
+	scope Scope,
+	opts RegistrationOptions,
 ) error {
 	dc.mu.Lock()
 	defer dc.mu.Unlock()
 
+	registration, returnType, err := newRegistration(constructor, scope, opts)
+	if err != nil {
+		return err
+	}
+
+	if opts.Group != "" {
+		if opts.Name != "" || embedsMarker(returnType, outType) {
+			return fmt.Errorf("value group %q members must be plain, unnamed constructors", opts.Group)
+		}
+		dc.addGroupMember(opts.Group, returnType, registration)
+		return nil
+	}
+
+	// Result objects register each exported field in addition to the object itself
+	type entry struct {
+		name         string
+		t            reflect.Type
+		registration *Registration
+	}
+	entries := []entry{{opts.Name, returnType, registration}}
+	if embedsMarker(returnType, outType) {
+		fields, err := outFields(returnType)
+		if err != nil {
+			return err
+		}
+		outKey := nodeKey{t: returnType, name: opts.Name}
+		for _, field := range fields {
+			fieldRegistration := newOutFieldRegistration(outKey, field, scope)
+			fieldRegistration.module = opts.Module
+			fieldRegistration.fn = registration.fn
+			fieldRegistration.source = registration.source
+			fieldRegistration.caller = registration.caller
+			entries = append(entries, entry{field.Tag.Get("name"), field.Type, fieldRegistration})
+		}
+	}
+
+	// Registering the same constructor again the same way changes nothing
+	if existing, ok := dc.lookupLocal(opts.Name, returnType); ok && !opts.Replace && existing.sameAs(registration) {
+		return nil
+	}
+
+	// Check every entry first so a rejected result object registers nothing
+	if !opts.Replace {
+		for _, e := range entries {
+			if err := dc.checkDuplicate(e.name, e.t, e.registration); err != nil {
+				return err
+			}
+		}
+	}
+	for _, e := range entries {
+		dc.storeRegistration(e.name, e.t, e.registration)
+	}
+	return nil
+}
+
+// checkDuplicate fails if another registration already serves t under name.
+// Callers must hold dc.mu (read).
+func (dc *DependencyContainer) checkDuplicate(name string, t reflect.Type, registration *Registration) error {
+	existing, exists := dc.lookupLocal(name, t)
+	if !exists {
+		return nil
+	}
+	return &DuplicateRegistrationError{
+		Type:      t,
+		Name:      name,
+		Existing:  existing.describe(),
+		Duplicate: registration.describe(),
+	}
+}
+
+// lookupLocal returns dc's own registration of t under name ("" for unnamed).
+// Callers must hold dc.mu (read).
+func (dc *DependencyContainer) lookupLocal(name string, t reflect.Type) (*Registration, bool) {
+	if name != "" {
+		registration, ok := dc.namedConstructors[name][t]
+		return registration, ok
+	}
+	registration, ok := dc.constructors[t]
+	return registration, ok
+}
+
+// sameAs reports whether other registers the same constructor function as r, with the same scope,
+// parameter qualifiers and module. Two closures made from one literal are different functions:
+// they share a name and position, yet may capture different values.
+func (r *Registration) sameAs(other *Registration) bool {
+	if r.supplied || other.supplied || !sameFunc(r.fn, other.fn) {
+		return false
+	}
+	if r.scope != other.scope || r.module != other.module {
+		return false
+	}
+	deps, otherDeps := r.dependencies(), other.dependencies()
+	if len(deps) != len(otherDeps) {
+		return false
+	}
+	for i := range deps {
+		if deps[i].key != otherDeps[i].key || deps[i].optional != otherDeps[i].optional {
+			return false
+		}
+	}
+	return true
+}
+
+// storeRegistration records a registration as unnamed or named, invalidating stale named instances.
+// Callers must hold dc.mu (write).
+func (dc *DependencyContainer) storeRegistration(name string, t reflect.Type, registration *Registration) {
+	dc.forgetFailures()
+	if name == "" {
+		dc.constructors[t] = registration
+		return
+	}
+
+	if dc.namedConstructors[name] == nil {
+		dc.namedConstructors[name] = make(map[reflect.Type]*Registration)
+	}
+	dc.namedConstructors[name][t] = registration
+
+	// Invalidate caches for this named dependency
+	dc.evictNamedInstances(name, t)
+}
+
+// newRegistration validates a constructor signature and wraps it to work with the container
+func newRegistration(constructor interface{}, scope Scope, opts RegistrationOptions) (*Registration, reflect.Type, error) {
 	constructorType := reflect.TypeOf(constructor)
 
 	// Special case: detect if someone passed a slice of constructors
 	if constructorType != nil && (constructorType.Kind() == reflect.Slice || constructorType.Kind() == reflect.Array) {
-		return fmt.Errorf("constructor must be a function, got %v. Did you mean to pass individual constructors instead of a slice? Use InitWithScope() or spread the slice elements", constructorType)
+		return nil, nil, fmt.Errorf("constructor must be a function, got %v. Did you mean to pass individual constructors instead of a slice? Use InitWithScope() or spread the slice elements", constructorType)
 	}
 
-	if constructorType.Kind() != reflect.Func {
-		return fmt.Errorf("constructor must be a function, got %T", constructor)
+	if constructorType == nil || constructorType.Kind() != reflect.Func {
+		return nil, nil, fmt.Errorf("constructor must be a function, got %T", constructor)
 	}
 
 	// Validate constructor signature: must return (T) or (T, error)
 	if constructorType.NumOut() == 0 || constructorType.NumOut() > 2 {
-		return fmt.Errorf("constructor %v must return either (T) or (T, error), but returns %d values", constructorType, constructorType.NumOut())
+		return nil, nil, fmt.Errorf("constructor %v must return either (T) or (T, error), but returns %d values", constructorType, constructorType.NumOut())
 	}
 	if constructorType.NumOut() == 2 {
-		if !constructorType.Out(1).Implements(reflect.TypeOf((*error)(nil)).Elem()) {
-			return fmt.Errorf("constructor %v: second return value must be of type error, got %v", constructorType, constructorType.Out(1))
+		if !constructorType.Out(1).Implements(errorType) {
+			return nil, nil, fmt.Errorf("constructor %v: second return value must be of type error, got %v", constructorType, constructorType.Out(1))
 		}
 	}
 
@@ -52,42 +196,85 @@ func (dc *DependencyContainer) RegisterConstructorWithScope(
 		paramTypes[i] = constructorType.In(i)
 	}
 
+	if len(opts.ParamNames) > numIn {
+		return nil, nil, fmt.Errorf("constructor %v has %d parameters, but %d parameter names were given", constructorType, numIn, len(opts.ParamNames))
+	}
+	params := make([]param, numIn)
+	for i := 0; i < numIn; i++ {
+		name := ""
+		if i < len(opts.ParamNames) {
+			name = opts.ParamNames[i]
+		}
+		p, err := newParam(paramTypes[i], name)
+		if err != nil {
+			return nil, nil, fmt.Errorf("constructor %v: %w", constructorType, err)
+		}
+		params[i] = p
+	}
+
+	caller := opts.Caller
+	if caller == "" {
+		caller = CallerLocation()
+	}
+
 	// Wrap the constructor to work with the container
-	wrappedConstructor := func(container *DependencyContainer, scopeID string, stack []reflect.Type) (interface{}, error) {
+	wrappedConstructor := func(ctx context.Context, container *DependencyContainer, scopeID string, stack []nodeKey) (interface{}, error) {
+		if ctx.Err() != nil {
+			return nil, contextError(ctx, stack)
+		}
+
 		// Use reflection to call the constructor with dependencies
 		constructorValue := reflect.ValueOf(constructor)
 
 		// Prepare arguments for the constructor
+		call := &constructorCall{ctx: ctx, stack: stack}
+		defer call.done.Store(true)
 		args := make([]reflect.Value, numIn)
-		for i := 0; i < numIn; i++ {
-			argType := paramTypes[i]
-			arg, err := container.resolveWithScope(argType, scopeID, stack)
+		for i, p := range params {
+			arg, err := container.buildParam(p, scopeID, call)
 			if err != nil {
-				return nil, fmt.Errorf("error resolving dependency %v (parameter %d of %v): %w", argType, i+1, constructorType, err)
+				if opts.Name != "" {
+					return nil, fmt.Errorf("error resolving dependency %v for named %q: %w", p, opts.Name, err)
+				}
+				return nil, fmt.Errorf("error resolving dependency %v (parameter %d of %v): %w", p, i+1, constructorType, err)
 			}
-			args[i] = reflect.ValueOf(arg)
+			args[i] = arg
 		}
 
 		// Call the constructor
-		results := constructorValue.Call(args)
+		results, err := container.call(constructorValue, args, opts.Module, stack)
+		if err != nil {
+			return nil, err
+		}
 		// Handle (T) signature
 		if constructorType.NumOut() == 1 {
 			return results[0].Interface(), nil
 		}
 		// Handle (T, error) signature
 		if errVal := results[1]; !errVal.IsNil() {
-			return nil, errVal.Interface().(error)
+			return nil, &ConstructorError{
+				Type:      returnType,
+				Func:      funcName(constructorValue),
+				Location:  funcFile(constructorValue),
+				Module:    opts.Module,
+				Path:      stackPath(stack),
+				Locations: container.locations(stack),
+				Cause:     errVal.Interface().(error),
+			}
 		}
 		return results[0].Interface(), nil
 	}
 
-	dc.constructors[returnType] = &Registration{
+	return &Registration{
 		constructor: wrappedConstructor,
 		scope:       scope,
 		paramTypes:  paramTypes,
-	}
-
-	return nil
+		params:      params,
+		module:      opts.Module,
+		fn:          reflect.ValueOf(constructor),
+		source:      funcLocation(reflect.ValueOf(constructor)),
+		caller:      caller,
+	}, returnType, nil
 }
 
 // RegisterRuntimeConstructor allows registration of constructors after initialization
@@ -110,21 +297,14 @@ func (dc *DependencyContainer) OverrideConstructor(
 	returnType := constructorType.Out(0)
 
 	// Register it
-	if err := dc.RegisterConstructorWithScope(constructor, scope); err != nil {
+	if err := dc.RegisterConstructorWithOptions(constructor, scope, RegistrationOptions{Replace: true}); err != nil {
 		return err
 	}
 
-	// Invalidate caches
+	// Invalidate caches, including interfaces bound to the type
 	dc.mu.Lock()
 	defer dc.mu.Unlock()
-
-	// Clear from singleton cache
-	delete(dc.dependencies, returnType)
-
-	// Clear from all scope caches
-	for _, scopeCache := range dc.scopedInstances {
-		delete(scopeCache, returnType)
-	}
+	dc.evictInstances(returnType)
 
 	return nil
 }
@@ -135,72 +315,136 @@ func (dc *DependencyContainer) RegisterNamedConstructorWithScope(
 	constructor interface{},
 	scope Scope,
 ) error {
+	return dc.RegisterConstructorWithOptions(constructor, scope, RegistrationOptions{Name: name})
+}
+
+// RegisterInstance registers an existing value as a singleton of type t.
+// Supplied values are returned as-is and are never closed by the container.
+// Like constructors, a value cannot take the place of an existing registration.
+func (dc *DependencyContainer) RegisterInstance(t reflect.Type, instance interface{}) error {
+	if err := checkInstance(t, instance); err != nil {
+		return err
+	}
+
 	dc.mu.Lock()
 	defer dc.mu.Unlock()
 
-	constructorType := reflect.TypeOf(constructor)
-	if constructorType.Kind() != reflect.Func {
-		return fmt.Errorf("constructor must be a function, got %T", constructor)
+	registration := newInstanceRegistration(instance)
+	registration.caller = CallerLocation()
+	if err := dc.checkDuplicate("", t, registration); err != nil {
+		return err
 	}
+	dc.constructors[t] = registration
+	dc.forgetFailures()
 
-	// Validate constructor signature
-	if constructorType.NumOut() == 0 || constructorType.NumOut() > 2 {
-		return fmt.Errorf("constructor %v must return either (T) or (T, error)", constructorType)
-	}
-	if constructorType.NumOut() == 2 {
-		if !constructorType.Out(1).Implements(reflect.TypeOf((*error)(nil)).Elem()) {
-			return fmt.Errorf("constructor %v: second return value must be of type error", constructorType)
-		}
-	}
+	// Invalidate any instance cached by a previous constructor
+	dc.evictInstances(t)
 
-	returnType := constructorType.Out(0)
-	numIn := constructorType.NumIn()
-	paramTypes := make([]reflect.Type, numIn)
-	for i := 0; i < numIn; i++ {
-		paramTypes[i] = constructorType.In(i)
+	return nil
+}
+
+// RegisterNamedInstance registers an existing value as a named singleton of type t
+func (dc *DependencyContainer) RegisterNamedInstance(name string, t reflect.Type, instance interface{}) error {
+	if err := checkInstance(t, instance); err != nil {
+		return err
 	}
 
-	wrappedConstructor := func(container *DependencyContainer, scopeID string, stack []reflect.Type) (interface{}, error) {
-		constructorValue := reflect.ValueOf(constructor)
-		args := make([]reflect.Value, numIn)
-		for i := 0; i < numIn; i++ {
-			argType := paramTypes[i]
-			arg, err := container.resolveWithScope(argType, scopeID, stack)
-			if err != nil {
-				return nil, fmt.Errorf("error resolving dependency %v for named %q: %w", argType, name, err)
-			}
-			args[i] = reflect.ValueOf(arg)
-		}
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
 
-		results := constructorValue.Call(args)
-		if constructorType.NumOut() == 1 {
-			return results[0].Interface(), nil
-		}
-		if errVal := results[1]; !errVal.IsNil() {
-			return nil, errVal.Interface().(error)
-		}
-		return results[0].Interface(), nil
+	registration := newInstanceRegistration(instance)
+	registration.caller = CallerLocation()
+	if err := dc.checkDuplicate(name, t, registration); err != nil {
+		return err
 	}
-
 	if dc.namedConstructors[name] == nil {
 		dc.namedConstructors[name] = make(map[reflect.Type]*Registration)
 	}
+	dc.namedConstructors[name][t] = registration
+	dc.forgetFailures()
 
-	dc.namedConstructors[name][returnType] = &Registration{
-		constructor: wrappedConstructor,
-		scope:       scope,
-		paramTypes:  paramTypes,
+	// Invalidate caches for this named dependency
+	dc.evictNamedInstances(name, t)
+
+	return nil
+}
+
+func checkInstance(t reflect.Type, instance interface{}) error {
+	if t == nil || instance == nil {
+		return fmt.Errorf("cannot supply a nil value")
+	}
+	if !reflect.TypeOf(instance).AssignableTo(t) {
+		return fmt.Errorf("supplied value of type %T is not assignable to %v", instance, t)
 	}
+	return nil
+}
 
-	// Invalidate caches for this named dependency
-	if dc.namedDependencies[name] != nil {
-		delete(dc.namedDependencies[name], returnType)
+func newInstanceRegistration(instance interface{}) *Registration {
+	return &Registration{
+		constructor: func(ctx context.Context, container *DependencyContainer, scopeID string, stack []nodeKey) (interface{}, error) {
+			return instance, nil
+		},
+		scope:    Singleton,
+		supplied: true,
+		instance: instance,
 	}
-	for _, scopeCache := range dc.namedScopedInstances {
-		if namedCache, ok := scopeCache[name]; ok {
-			delete(namedCache, returnType)
-		}
+}
+
+// Snapshot records dc's registrations (constructors, values, bindings, value groups and decorators)
+// and returns a function that restores them, undoing anything registered in between. Instances
+// built from registrations that the restore removes are dropped from the caches.
+func (dc *DependencyContainer) Snapshot() (restore func()) {
+	dc.mu.RLock()
+	defer dc.mu.RUnlock()
+
+	constructors := maps.Clone(dc.constructors)
+	namedConstructors := make(map[string]map[reflect.Type]*Registration, len(dc.namedConstructors))
+	for name, registrations := range dc.namedConstructors {
+		namedConstructors[name] = maps.Clone(registrations)
+	}
+	interfaceBindings := maps.Clone(dc.interfaceBindings)
+	namedInterfaceBindings := make(map[string]map[reflect.Type]reflect.Type, len(dc.namedInterfaceBindings))
+	for name, bindings := range dc.namedInterfaceBindings {
+		namedInterfaceBindings[name] = maps.Clone(bindings)
 	}
+	bindingModules := maps.Clone(dc.bindingModules)
+	// Registering only appends to these slices, so keeping their current lengths is enough
+	groups := maps.Clone(dc.groups)
+	decorators := maps.Clone(dc.decorators)
 
-	return nil
+	return func() {
+		dc.mu.Lock()
+		defer dc.mu.Unlock()
+
+		for t, registration := range dc.constructors {
+			if constructors[t] != registration || len(dc.decorators[t]) != len(decorators[t]) {
+				dc.evictInstances(t)
+			}
+		}
+		for name, registrations := range dc.namedConstructors {
+			for t, registration := range registrations {
+				if namedConstructors[name][t] != registration {
+					dc.evictNamedInstances(name, t)
+				}
+			}
+		}
+		for group, members := range dc.groups {
+			for _, m := range members[len(groups[group]):] {
+				for key := range dc.groupInstances {
+					if key.member == m.id {
+						delete(dc.groupInstances, key)
+					}
+				}
+			}
+		}
+
+		dc.constructors = constructors
+		dc.namedConstructors = namedConstructors
+		dc.interfaceBindings = interfaceBindings
+		dc.namedInterfaceBindings = namedInterfaceBindings
+		dc.bindingModules = bindingModules
+		dc.groups = groups
+		dc.decorators = decorators
+		dc.forgetFailures()
+	}
 }
@@ -1,28 +1,42 @@
 package container
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 )
 
 // ResolveNamed resolves a dependency by name (for named interface bindings)
 func (dc *DependencyContainer) ResolveNamed(name string, t reflect.Type) (interface{}, error) {
-	return dc.resolveNamedWithScope(name, t, "", nil)
+	return dc.resolveNamedWithScope(context.Background(), name, t, "", nil)
 }
 
 // ResolveNamedWithScope resolves a named dependency with a specific scope
 func (dc *DependencyContainer) ResolveNamedWithScope(name string, t reflect.Type, scopeID string) (interface{}, error) {
-	return dc.resolveNamedWithScope(name, t, scopeID, nil)
+	return dc.resolveNamedWithScope(context.Background(), name, t, scopeID, nil)
+}
+
+// ResolveNamedCtx resolves a named dependency with a context (see ResolveCtx)
+func (dc *DependencyContainer) ResolveNamedCtx(ctx context.Context, name string, t reflect.Type, scopeID string) (interface{}, error) {
+	return dc.resolveNamedWithScope(ctx, name, t, scopeID, nil)
 }
 
 // resolveNamedWithScope internal method to resolve named dependencies
-func (dc *DependencyContainer) resolveNamedWithScope(name string, t reflect.Type, scopeID string, stack []reflect.Type) (interface{}, error) {
+func (dc *DependencyContainer) resolveNamedWithScope(ctx context.Context, name string, t reflect.Type, scopeID string, stack []nodeKey) (interface{}, error) {
+	// 0. Names this container does not register are resolved by the ancestor that does
+	dc.mu.RLock()
+	owner := dc.delegate(nodeKey{t: t, name: name})
+	dc.mu.RUnlock()
+	if owner != nil {
+		return owner.resolveNamedWithScope(ctx, name, t, scopeID, stack)
+	}
+
 	// 1. Check if this is an interface type with a named binding
 	if t.Kind() == reflect.Interface {
 		concreteType, exists := dc.GetNamedInterfaceBinding(name, t)
 		if exists {
 			// Resolve the concrete type instead
-			return dc.resolveWithScope(concreteType, scopeID, stack)
+			return dc.resolveWithScope(ctx, concreteType, scopeID, stack)
 		}
 	}
 
@@ -38,102 +52,92 @@ func (dc *DependencyContainer) resolveNamedWithScope(name string, t reflect.Type
 	if !exists {
 		// Fallback: If no named constructor, but it's an interface, return error
 		if t.Kind() == reflect.Interface {
-			return nil, fmt.Errorf("no binding found for interface %v with name %q", t, name)
+			return nil, dc.missingError(t, name, stack)
 		}
 		// Fallback: Resolve normally (unnamed)
-		return dc.resolveWithScope(t, scopeID, stack)
+		return dc.resolveWithScope(ctx, t, scopeID, stack)
+	}
+
+	if registration.supplied {
+		return registration.instance, nil
 	}
 
+	// Named nodes take part in cycle detection like unnamed ones
+	key := nodeKey{t: t, name: name}
+	if err := dc.checkCycle(key, stack); err != nil {
+		return nil, err
+	}
+	newStack := append(stack, key)
+
 	// 3. Handle named resolution with separate caches
 	switch registration.scope {
 	case Singleton:
-		return dc.resolveNamedSingleton(name, t, registration, stack)
+		return dc.resolveNamedSingleton(ctx, name, t, registration, newStack)
 	case Transient:
-		return registration.constructor(dc, scopeID, stack)
+		return registration.constructor(ctx, dc, scopeID, newStack)
 	case Scoped:
-		return dc.resolveNamedScoped(name, t, registration, scopeID, stack)
+		return dc.resolveNamedScoped(ctx, name, t, registration, scopeID, newStack)
 	default:
 		return nil, fmt.Errorf("unknown scope type for named %v", t)
 	}
 }
 
-func (dc *DependencyContainer) resolveNamedSingleton(name string, t reflect.Type, registration *Registration, stack []reflect.Type) (interface{}, error) {
-	// Fast path
-	dc.mu.RLock()
-	if typeMap, exists := dc.namedDependencies[name]; exists {
-		if dep, exists := typeMap[t]; exists {
-			dc.mu.RUnlock()
-			return dep, nil
-		}
-	}
-	dc.mu.RUnlock()
-
-	// Slow path: For named singletons, we also use the ResolveNamed entry point
-	// which will call resolveNamedWithScope -> resolveNamedSingleton.
-	// We need to protect against concurrent creation of named singletons too.
-	// For simplicity, we can use the same dc.mu Lock for the whole creation.
-	dc.mu.Lock()
-	defer dc.mu.Unlock()
-
-	// Double-check
-	if typeMap, exists := dc.namedDependencies[name]; exists {
-		if dep, exists := typeMap[t]; exists {
-			return dep, nil
-		}
-	} else {
-		if dc.namedDependencies[name] == nil {
-			dc.namedDependencies[name] = make(map[reflect.Type]interface{})
-		}
-	}
+func (dc *DependencyContainer) resolveNamedSingleton(ctx context.Context, name string, t reflect.Type, registration *Registration, stack []nodeKey) (interface{}, error) {
+	return dc.resolveCached(ctx, instanceKey{name: name, t: t}, stack, func() (interface{}, error) {
+		return registration.constructor(ctx, dc, "", stack)
+	})
+}
 
-	// Create instance
-	instance, err := registration.constructor(dc, "", stack)
-	if err != nil {
-		return nil, err
+func (dc *DependencyContainer) resolveNamedScoped(ctx context.Context, name string, t reflect.Type, registration *Registration, scopeID string, stack []nodeKey) (interface{}, error) {
+	if scopeID == "" {
+		return nil, &ScopeRequiredError{Type: t, Name: name}
 	}
 
-	dc.namedDependencies[name][t] = instance
-	return instance, nil
+	return dc.resolveCached(ctx, instanceKey{scopeID: scopeID, name: name, t: t}, stack, func() (interface{}, error) {
+		return registration.constructor(ctx, dc, scopeID, stack)
+	})
 }
 
-func (dc *DependencyContainer) resolveNamedScoped(name string, t reflect.Type, registration *Registration, scopeID string, stack []reflect.Type) (interface{}, error) {
-	if scopeID == "" {
-		return nil, fmt.Errorf("scope ID required for named scoped dependency %v (%s)", t, name)
+// lookupRegistration follows interface bindings and returns the registration that serves key.
+// Callers must hold dc.mu (read).
+func (dc *DependencyContainer) lookupRegistration(key nodeKey) (nodeKey, *Registration, bool) {
+	if key.group != "" {
+		if key.index > 0 {
+			member, ok := dc.localGroupMember(key)
+			return key, member.reg, ok
+		}
+		reg, err := dc.groupRegistration(key)
+		return key, reg, err == nil
 	}
 
-	dc.mu.RLock()
-	if scopeMap, exists := dc.namedScopedInstances[scopeID]; exists {
-		if typeMap, exists := scopeMap[name]; exists {
-			if dep, exists := typeMap[t]; exists {
-				dc.mu.RUnlock()
-				return dep, nil
+	if key.name != "" {
+		if key.t.Kind() == reflect.Interface {
+			if concreteType, ok := dc.namedInterfaceBindings[key.name][key.t]; ok {
+				return dc.lookupUnnamed(concreteType)
 			}
 		}
+		if reg, ok := dc.namedConstructors[key.name][key.t]; ok {
+			return key, reg, true
+		}
+		// Named resolution falls back to the unnamed registration
 	}
-	dc.mu.RUnlock()
-
-	dc.mu.Lock()
-	defer dc.mu.Unlock()
-
-	// Ensure maps exist
-	if _, exists := dc.namedScopedInstances[scopeID]; !exists {
-		dc.namedScopedInstances[scopeID] = make(map[string]map[reflect.Type]interface{})
-	}
-	if _, exists := dc.namedScopedInstances[scopeID][name]; !exists {
-		dc.namedScopedInstances[scopeID][name] = make(map[reflect.Type]interface{})
-	}
+	return dc.lookupUnnamed(key.t)
+}
 
-	// Double-check
-	if dep, exists := dc.namedScopedInstances[scopeID][name][t]; exists {
-		return dep, nil
+// lookupUnnamed returns the registration serving unnamed type t: its decorator chain if it has
+// one, otherwise its constructor (after following an interface binding). Callers must hold dc.mu (read).
+func (dc *DependencyContainer) lookupUnnamed(t reflect.Type) (nodeKey, *Registration, bool) {
+	if reg, ok := dc.decoratedRegistration(t); ok {
+		return nodeKey{t: t}, reg, true
 	}
-
-	// Create instance
-	instance, err := registration.constructor(dc, scopeID, stack)
-	if err != nil {
-		return nil, err
+	if t.Kind() == reflect.Interface {
+		if concreteType, ok := dc.interfaceBindings[t]; ok {
+			if reg, ok := dc.decoratedRegistration(concreteType); ok {
+				return nodeKey{t: concreteType}, reg, true
+			}
+			t = concreteType
+		}
 	}
-
-	dc.namedScopedInstances[scopeID][name][t] = instance
-	return instance, nil
+	reg, ok := dc.constructors[t]
+	return nodeKey{t: t}, reg, ok
 }
@@ -0,0 +1,74 @@
+package container
+
+import (
+	"fmt"
+	"reflect"
+	"runtime/debug"
+)
+
+// ConstructorPanicError reports a constructor (or decorator) that panicked. Panics are recovered
+// unless the container was told to crash with SetCrashOnPanic.
+type ConstructorPanicError struct {
+	Type      reflect.Type      // Type being built
+	Func      string            // Name of the function that panicked
+	Location  string            // file:line of the function
+	Module    string            // Module that registered the function ("" if none)
+	Path      []string          // Resolution chain, ending with the node being built
+	Locations map[string]string // Where the nodes in Path are registered
+	Value     interface{}       // Value passed to panic
+	Stack     []byte            // Stack trace of the panicking goroutine
+}
+
+func (e *ConstructorPanicError) Error() string {
+	fn := e.Func
+	if e.Location != "" {
+		fn += " (" + e.Location + ")"
+	}
+	msg := fmt.Sprintf("constructor %s for %v panicked: %v", fn, e.Type, e.Value)
+	if e.Module != "" {
+		msg = fmt.Sprintf("constructor %s for %v (module %s) panicked: %v", fn, e.Type, e.Module, e.Value)
+	}
+	return msg + resolutionPath(e.Path) + where(e.Path, e.Locations)
+}
+
+// Unwrap returns the panic value if it is an error, so errors.Is and errors.As see through the panic
+func (e *ConstructorPanicError) Unwrap() error {
+	if err, ok := e.Value.(error); ok {
+		return err
+	}
+	return nil
+}
+
+// SetCrashOnPanic disables panic recovery: a panicking constructor or decorator then unwinds
+// through Resolve and crashes the caller, as a plain function call would.
+func (dc *DependencyContainer) SetCrashOnPanic(crash bool) {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+	dc.crashOnPanic = crash
+}
+
+// call invokes a constructor or decorator for the node on top of stack, converting a panic
+// into a *ConstructorPanicError unless the container is set to crash
+func (dc *DependencyContainer) call(fn reflect.Value, args []reflect.Value, module string, stack []nodeKey) (results []reflect.Value, err error) {
+	dc.mu.RLock()
+	crash := dc.crashOnPanic
+	dc.mu.RUnlock()
+
+	if !crash {
+		defer func() {
+			if r := recover(); r != nil {
+				err = &ConstructorPanicError{
+					Type:      fn.Type().Out(0),
+					Func:      funcName(fn),
+					Location:  funcFile(fn),
+					Module:    module,
+					Path:      stackPath(stack),
+					Locations: dc.locations(stack),
+					Value:     r,
+					Stack:     debug.Stack(),
+				}
+			}
+		}()
+	}
+	return fn.Call(args), nil
+}
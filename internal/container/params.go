@@ -0,0 +1,363 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync/atomic"
+)
+
+// In marks a parameter object: a struct whose exported fields are each resolved from the container.
+// Fields may be qualified with `name:"..."`, marked `optional:"true"`, or receive
+// every member of a value group as a slice with `group:"..."`.
+type In struct{}
+
+// Out marks a result object: a struct whose exported fields each become a registration.
+// Fields may be registered under a name with `name:"..."`.
+type Out struct{}
+
+// OptionalMarker identifies an optional wrapper type such as di.Optional[T]: a struct with a
+// field of type OptionalMarker, an exported Value field and an exported Ok bool field.
+// Wrapped dependencies resolve to an absent value (Ok == false) when nothing is registered.
+type OptionalMarker struct{}
+
+var (
+	inType             = reflect.TypeOf(In{})
+	outType            = reflect.TypeOf(Out{})
+	optionalMarkerType = reflect.TypeOf(OptionalMarker{})
+	errorType          = reflect.TypeOf((*error)(nil)).Elem()
+	contextType        = reflect.TypeOf((*context.Context)(nil)).Elem()
+)
+
+// dependency is a single value a constructor needs from the container
+type dependency struct {
+	key      nodeKey
+	optional bool         // Resolve to the zero value when nothing is registered
+	wrapper  reflect.Type // Optional wrapper the resolved value is delivered in (nil if none)
+	lazy     reflect.Type // Func type that resolves the value on demand (nil if resolved eagerly)
+}
+
+// newDependency describes a dependency on a value of type t, unwrapping optional wrappers
+func newDependency(t reflect.Type, name, group string, optional bool) dependency {
+	if valueType, ok := optionalValueType(t); ok {
+		return dependency{key: nodeKey{t: valueType, name: name, group: group}, optional: true, wrapper: t}
+	}
+	if valueType, ok := lazyValueType(t); ok {
+		return dependency{key: nodeKey{t: valueType, name: name, group: group}, optional: optional, lazy: t}
+	}
+	return dependency{key: nodeKey{t: t, name: name, group: group}, optional: optional}
+}
+
+// optionalValueType returns the type of the Value field if t is an optional wrapper
+func optionalValueType(t reflect.Type) (reflect.Type, bool) {
+	if t.Kind() != reflect.Struct {
+		return nil, false
+	}
+	marked := false
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Type == optionalMarkerType {
+			marked = true
+			break
+		}
+	}
+	if !marked {
+		return nil, false
+	}
+	value, hasValue := t.FieldByName("Value")
+	ok, hasOk := t.FieldByName("Ok")
+	if !hasValue || !hasOk || ok.Type.Kind() != reflect.Bool {
+		return nil, false
+	}
+	return value.Type, true
+}
+
+// lazyValueType returns T if t is a lazy accessor: func() T, func() (T, error) or a named
+// type with one of those signatures, such as di.Provider[T]
+func lazyValueType(t reflect.Type) (reflect.Type, bool) {
+	if t.Kind() != reflect.Func || t.NumIn() != 0 || t.IsVariadic() {
+		return nil, false
+	}
+	switch {
+	case t.NumOut() == 1 && t.Out(0) != errorType:
+		return t.Out(0), true
+	case t.NumOut() == 2 && t.Out(1) == errorType:
+		return t.Out(0), true
+	}
+	return nil, false
+}
+
+// served returns the dependency as dc resolves it: a zero-argument func parameter is only a lazy
+// accessor when the func type itself has no registration. Callers must hold dc.mu (read).
+func (dc *DependencyContainer) served(dep dependency) dependency {
+	if dep.lazy == nil {
+		return dep
+	}
+	funcKey := nodeKey{t: dep.lazy, name: dep.key.name, group: dep.key.group}
+	if _, _, _, ok := dc.lookupOwner(funcKey); ok {
+		return dependency{key: funcKey, optional: dep.optional}
+	}
+	return dep
+}
+
+// servedDependencies returns every value reg resolves in dc (see served). Callers must hold dc.mu (read).
+func (dc *DependencyContainer) servedDependencies(reg *Registration) []dependency {
+	deps := reg.dependencies()
+	for i, dep := range deps {
+		deps[i] = dc.served(dep)
+	}
+	return deps
+}
+
+// wrap delivers a resolved value in the dependency's optional wrapper, if it has one
+func (dep dependency) wrap(value reflect.Value, present bool) reflect.Value {
+	if dep.wrapper == nil {
+		return value
+	}
+	w := reflect.New(dep.wrapper).Elem()
+	w.FieldByName("Value").Set(value)
+	w.FieldByName("Ok").SetBool(present)
+	return w
+}
+
+// param describes how one constructor argument is built
+type param struct {
+	t       reflect.Type
+	dep     dependency // Plain parameter
+	fields  []inField  // Fields of a di.In parameter object (nil for plain parameters)
+	context bool       // context.Context of the current resolution (not a dependency)
+}
+
+// inField is one resolved field of a parameter object
+type inField struct {
+	index   int
+	dep     dependency
+	context bool // context.Context of the current resolution (not a dependency)
+}
+
+func (p param) String() string {
+	if p.fields != nil || p.context {
+		return p.t.String()
+	}
+	return p.dep.key.String()
+}
+
+// dependencies returns every dependency needed to build the parameter
+func (p param) dependencies() []dependency {
+	if p.context {
+		return nil
+	}
+	if p.fields == nil {
+		return []dependency{p.dep}
+	}
+	deps := make([]dependency, 0, len(p.fields))
+	for _, f := range p.fields {
+		if !f.context {
+			deps = append(deps, f.dep)
+		}
+	}
+	return deps
+}
+
+// embedsMarker reports whether t is a struct embedding the given marker type
+func embedsMarker(t reflect.Type, marker reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if f := t.Field(i); f.Anonymous && f.Type == marker {
+			return true
+		}
+	}
+	return false
+}
+
+// newParam describes a constructor parameter, expanding di.In parameter objects into their fields
+func newParam(t reflect.Type, name string) (param, error) {
+	if t == contextType {
+		return param{t: t, context: true}, nil
+	}
+	if !embedsMarker(t, inType) {
+		return param{t: t, dep: newDependency(t, name, "", false)}, nil
+	}
+	if name != "" {
+		return param{}, fmt.Errorf("parameter object %v cannot be named; qualify its fields with `name` tags instead", t)
+	}
+
+	p := param{t: t, fields: []inField{}}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous && f.Type == inType {
+			continue
+		}
+		if !f.IsExported() {
+			return param{}, fmt.Errorf("field %s of parameter object %v must be exported", f.Name, t)
+		}
+		if f.Type == contextType {
+			p.fields = append(p.fields, inField{index: i, context: true})
+			continue
+		}
+
+		optional := false
+		if value, ok := f.Tag.Lookup("optional"); ok {
+			var err error
+			if optional, err = strconv.ParseBool(value); err != nil {
+				return param{}, fmt.Errorf("invalid optional tag on field %s of parameter object %v: %w", f.Name, t, err)
+			}
+		}
+
+		dep := newDependency(f.Type, f.Tag.Get("name"), f.Tag.Get("group"), optional)
+		if dep.key.group != "" {
+			if dep.key.name != "" {
+				return param{}, fmt.Errorf("field %s of parameter object %v cannot have both name and group tags", f.Name, t)
+			}
+			if dep.key.t.Kind() != reflect.Slice {
+				return param{}, fmt.Errorf("field %s of parameter object %v must be a slice to receive value group %q", f.Name, t, dep.key.group)
+			}
+		}
+
+		p.fields = append(p.fields, inField{index: i, dep: dep})
+	}
+	return p, nil
+}
+
+// constructorCall is the resolution state shared by the parameters of one constructor call.
+// Lazy parameters resolve as part of the caller's chain while the constructor runs (so calling
+// them eagerly reports cycles instead of deadlocking) and start a fresh chain afterwards.
+type constructorCall struct {
+	ctx   context.Context
+	stack []nodeKey
+	done  atomic.Bool
+}
+
+// lazy returns the context and stack a lazy parameter resolves with
+func (call *constructorCall) lazy() (context.Context, []nodeKey) {
+	if call.done.Load() {
+		return context.Background(), nil
+	}
+	return call.ctx, call.stack
+}
+
+// buildParam resolves the value of a constructor parameter
+func (dc *DependencyContainer) buildParam(p param, scopeID string, call *constructorCall) (reflect.Value, error) {
+	if p.context {
+		return contextValue(call.ctx), nil
+	}
+	if p.fields == nil {
+		return dc.buildDependency(p.dep, scopeID, call)
+	}
+
+	obj := reflect.New(p.t).Elem()
+	for _, f := range p.fields {
+		if f.context {
+			obj.Field(f.index).Set(contextValue(call.ctx))
+			continue
+		}
+		value, err := dc.buildDependency(f.dep, scopeID, call)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("field %s: %w", p.t.Field(f.index).Name, err)
+		}
+		obj.Field(f.index).Set(value)
+	}
+	return obj, nil
+}
+
+// contextValue returns ctx as a value of type context.Context
+func contextValue(ctx context.Context) reflect.Value {
+	value := reflect.New(contextType).Elem()
+	value.Set(reflect.ValueOf(ctx))
+	return value
+}
+
+// buildDependency resolves a dependency, or synthesizes an accessor bound to scopeID for lazy ones
+func (dc *DependencyContainer) buildDependency(dep dependency, scopeID string, call *constructorCall) (reflect.Value, error) {
+	dc.mu.RLock()
+	dep = dc.served(dep)
+	dc.mu.RUnlock()
+	if dep.lazy == nil {
+		return dc.resolveDependency(call.ctx, dep, scopeID, call.stack)
+	}
+
+	eager := dep
+	eager.lazy = nil
+	fnType := dep.lazy
+	return reflect.MakeFunc(fnType, func([]reflect.Value) []reflect.Value {
+		result := reflect.New(dep.key.t).Elem()
+		ctx, stack := call.lazy()
+		value, err := dc.resolveDependency(ctx, eager, scopeID, stack)
+		if err == nil {
+			result.Set(value)
+		}
+
+		if fnType.NumOut() == 1 {
+			// func() T has no way to report the error
+			if err != nil {
+				panic(fmt.Errorf("lazy resolution of %v failed: %w", dep.key, err))
+			}
+			return []reflect.Value{result}
+		}
+		errValue := reflect.New(errorType).Elem()
+		if err != nil {
+			errValue.Set(reflect.ValueOf(err))
+		}
+		return []reflect.Value{result, errValue}
+	}), nil
+}
+
+// resolveDependency resolves a single dependency into a value assignable to its type
+func (dc *DependencyContainer) resolveDependency(ctx context.Context, dep dependency, scopeID string, stack []nodeKey) (reflect.Value, error) {
+	if dep.optional && !dc.isRegistered(dep.key) {
+		return dep.wrap(reflect.Zero(dep.key.t), false), nil
+	}
+
+	instance, err := dc.resolveKeyWithStack(ctx, dep.key, scopeID, stack)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if instance == nil {
+		return dep.wrap(reflect.Zero(dep.key.t), true), nil
+	}
+	return dep.wrap(reflect.ValueOf(instance), true), nil
+}
+
+// isRegistered reports whether a node can be served by a registration (following interface bindings)
+func (dc *DependencyContainer) isRegistered(key nodeKey) bool {
+	dc.mu.RLock()
+	defer dc.mu.RUnlock()
+
+	_, _, _, ok := dc.lookupOwner(key)
+	return ok
+}
+
+// outFields returns the exported fields of a di.Out result object that become registrations
+func outFields(t reflect.Type) ([]reflect.StructField, error) {
+	var fields []reflect.StructField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous && f.Type == outType {
+			continue
+		}
+		if !f.IsExported() {
+			return nil, fmt.Errorf("field %s of result object %v must be exported", f.Name, t)
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+// newOutFieldRegistration registers one field of a result object. The field is read from the
+// result object resolved under outKey, so it shares the object's scope and caching.
+func newOutFieldRegistration(outKey nodeKey, field reflect.StructField, scope Scope) *Registration {
+	return &Registration{
+		constructor: func(ctx context.Context, container *DependencyContainer, scopeID string, stack []nodeKey) (interface{}, error) {
+			out, err := container.resolveKeyWithStack(ctx, outKey, scopeID, stack)
+			if err != nil {
+				return nil, err
+			}
+			return reflect.ValueOf(out).FieldByIndex(field.Index).Interface(), nil
+		},
+		scope:      scope,
+		paramTypes: []reflect.Type{outKey.t},
+		params:     []param{{t: outKey.t, dep: dependency{key: outKey}}},
+	}
+}
@@ -0,0 +1,132 @@
+package container
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ValidationReport lists every problem found in the dependency graph. It is returned as the
+// error from Validate; use errors.As to get at the structured sections.
+type ValidationReport struct {
+	Missing         []*MissingDependencyError // Types nothing is registered for, with every consumer
+	Cycles          []*CycleError             // Distinct circular dependencies
+	InvalidBindings []*InvalidBinding         // Interface bindings whose implementation cannot be built
+	Captive         []*CaptiveDependencyError // Singletons capturing scoped (or, if configured, transient) dependencies
+	Other           []error                   // Any other problem (e.g. invalid value groups)
+
+	// Warnings do not fail validation (e.g. singletons capturing transients under CaptureWarn)
+	Warnings []error
+}
+
+// InvalidBinding is an interface binding whose concrete type cannot be built
+type InvalidBinding struct {
+	Interface reflect.Type
+	Name      string // Binding name ("" if unnamed)
+	Concrete  reflect.Type
+	Reason    string
+	Module    string   // Module that made the binding ("" if none)
+	Consumers []string // Nodes that need the interface
+}
+
+func (b *InvalidBinding) binding() string {
+	binding := fmt.Sprintf("%v -> %v", b.Interface, b.Concrete)
+	if b.Name != "" {
+		binding = fmt.Sprintf("[%s]%v -> %v", b.Name, b.Interface, b.Concrete)
+	}
+	if b.Module != "" {
+		binding += " (module " + b.Module + ")"
+	}
+	return binding
+}
+
+func (b *InvalidBinding) Error() string {
+	return fmt.Sprintf("invalid interface binding %s: %s", b.binding(), b.Reason) + requiredBy(b.Consumers)
+}
+
+// Valid reports whether no problems were found
+func (r *ValidationReport) Valid() bool {
+	return len(r.Missing) == 0 && len(r.Cycles) == 0 && len(r.InvalidBindings) == 0 && len(r.Captive) == 0 && len(r.Other) == 0
+}
+
+// Errors returns every problem as an individual error: cycles first, then missing
+// dependencies, invalid bindings, captive dependencies and anything else. Warnings are not included.
+func (r *ValidationReport) Errors() []error {
+	var errs []error
+	for _, c := range r.Cycles {
+		errs = append(errs, c)
+	}
+	for _, m := range r.Missing {
+		errs = append(errs, m)
+	}
+	for _, b := range r.InvalidBindings {
+		errs = append(errs, b)
+	}
+	for _, c := range r.Captive {
+		errs = append(errs, c)
+	}
+	return append(errs, r.Other...)
+}
+
+// Unwrap exposes the individual problems to errors.Is and errors.As
+func (r *ValidationReport) Unwrap() []error {
+	return r.Errors()
+}
+
+func (r *ValidationReport) Error() string {
+	errs := r.Errors()
+	if len(errs) == 1 {
+		return errs[0].Error()
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "dependency graph has %d problems:", len(errs))
+	for _, err := range errs {
+		b.WriteString("\n  - " + err.Error())
+	}
+	return b.String()
+}
+
+// merge adds the problems of another report (e.g. a parent container's), combining the
+// consumers of nodes missing from both
+func (r *ValidationReport) merge(other *ValidationReport) {
+	for _, m := range other.Missing {
+		merged := false
+		for _, existing := range r.Missing {
+			if existing.node() == m.node() {
+				for _, consumer := range m.RequestedBy {
+					existing.RequestedBy = appendUnique(existing.RequestedBy, consumer)
+				}
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			r.Missing = append(r.Missing, m)
+		}
+	}
+	r.Cycles = append(r.Cycles, other.Cycles...)
+	r.InvalidBindings = append(r.InvalidBindings, other.InvalidBindings...)
+	r.Captive = append(r.Captive, other.Captive...)
+	r.Other = append(r.Other, other.Other...)
+	r.Warnings = append(r.Warnings, other.Warnings...)
+}
+
+// sort orders every section of the report for deterministic output
+func (r *ValidationReport) sort() {
+	sort.Slice(r.Missing, func(i, j int) bool { return r.Missing[i].node() < r.Missing[j].node() })
+	sort.Slice(r.Cycles, func(i, j int) bool {
+		return strings.Join(r.Cycles[i].Path, " ") < strings.Join(r.Cycles[j].Path, " ")
+	})
+	sort.Slice(r.InvalidBindings, func(i, j int) bool {
+		return r.InvalidBindings[i].binding() < r.InvalidBindings[j].binding()
+	})
+	sort.Slice(r.Captive, func(i, j int) bool { return r.Captive[i].Error() < r.Captive[j].Error() })
+	sort.Slice(r.Warnings, func(i, j int) bool { return r.Warnings[i].Error() < r.Warnings[j].Error() })
+	for _, m := range r.Missing {
+		sort.Strings(m.RequestedBy)
+	}
+	for _, b := range r.InvalidBindings {
+		sort.Strings(b.Consumers)
+	}
+}
@@ -1,179 +1,232 @@
 package container
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"reflect"
 )
 
 // Resolve public method to resolve dependencies (uses default/empty scope)
 func (dc *DependencyContainer) Resolve(t reflect.Type) (interface{}, error) {
-	return dc.resolveWithScope(t, "", nil)
+	return dc.resolveWithScope(context.Background(), t, "", nil)
 }
 
 // ResolveWithScope public method to resolve dependencies with a specific scope
 func (dc *DependencyContainer) ResolveWithScope(t reflect.Type, scopeID string) (interface{}, error) {
-	return dc.resolveWithScope(t, scopeID, nil)
+	return dc.resolveWithScope(context.Background(), t, scopeID, nil)
+}
+
+// ResolveCtx resolves a dependency with a context that is injected into constructors taking a
+// context.Context and that aborts the resolution (including waits on other builders) when done
+func (dc *DependencyContainer) ResolveCtx(ctx context.Context, t reflect.Type, scopeID string) (interface{}, error) {
+	return dc.resolveWithScope(ctx, t, scopeID, nil)
+}
+
+// resolveKeyWithStack resolves a graph node, dispatching on whether it carries a name or group qualifier
+func (dc *DependencyContainer) resolveKeyWithStack(ctx context.Context, key nodeKey, scopeID string, stack []nodeKey) (interface{}, error) {
+	if key.group != "" {
+		return dc.resolveGroupKey(ctx, key, scopeID, stack)
+	}
+	if key.name != "" {
+		return dc.resolveNamedWithScope(ctx, key.name, key.t, scopeID, stack)
+	}
+	return dc.resolveWithScope(ctx, key.t, scopeID, stack)
 }
 
 // resolveWithScope pkg method to resolve dependencies with scope support
-// @Param stack []reflect.Type - Call stack for the CURRENT resolution chain (local to goroutine)
-func (dc *DependencyContainer) resolveWithScope(t reflect.Type, scopeID string, stack []reflect.Type) (interface{}, error) {
+// @Param stack []nodeKey - Call stack for the CURRENT resolution chain (local to goroutine)
+func (dc *DependencyContainer) resolveWithScope(ctx context.Context, t reflect.Type, scopeID string, stack []nodeKey) (interface{}, error) {
+	// Types this container does not register are resolved by the ancestor that does;
+	// decorated types are built through their decorator chain
+	dc.mu.RLock()
+	owner := dc.delegate(nodeKey{t: t})
+	registration, decorated := dc.decoratedRegistration(t)
+	dc.mu.RUnlock()
+	if owner != nil {
+		return owner.resolveWithScope(ctx, t, scopeID, stack)
+	}
+
 	// Check if this is an interface type with a binding
-	if t.Kind() == reflect.Interface {
+	if !decorated && t.Kind() == reflect.Interface {
 		concreteType, exists := dc.GetInterfaceBinding(t)
 		if exists {
-			return dc.resolveWithScope(concreteType, scopeID, stack)
+			return dc.resolveWithScope(ctx, concreteType, scopeID, stack)
 		}
 	}
 
 	// 1. Check for circular dependencies in the CURRENT call stack
-	for _, stackType := range stack {
-		if stackType == t {
-			return nil, fmt.Errorf("circular dependency detected: %s", dc.formatDependencyChain(t, stack))
-		}
+	key := nodeKey{t: t}
+	if err := dc.checkCycle(key, stack); err != nil {
+		return nil, err
 	}
 
 	// 2. Find the registration for this type
-	dc.mu.RLock()
-	registration, exists := dc.constructors[t]
-	dc.mu.RUnlock()
+	exists := decorated
+	if !decorated {
+		dc.mu.RLock()
+		registration, exists = dc.constructors[t]
+		dc.mu.RUnlock()
+	}
 
 	if !exists {
-		return nil, fmt.Errorf("no constructor registered for type %v", t)
+		return nil, dc.missingError(t, "", stack)
+	}
+
+	// Supplied values are not constructed or cached
+	if registration.supplied {
+		return registration.instance, nil
 	}
 
 	// Update stack
-	newStack := append(stack, t)
+	newStack := append(stack, key)
 
 	// 3. Handle different scopes
 	switch registration.scope {
 	case Singleton:
-		return dc.resolveSingleton(t, registration, scopeID, newStack)
+		return dc.resolveSingleton(ctx, t, registration, scopeID, newStack)
 	case Transient:
-		return dc.resolveTransient(t, registration, scopeID, newStack)
+		return dc.resolveTransient(ctx, t, registration, scopeID, newStack)
 	case Scoped:
-		return dc.resolveScoped(t, registration, scopeID, newStack)
+		return dc.resolveScoped(ctx, t, registration, scopeID, newStack)
 	default:
 		return nil, fmt.Errorf("unknown scope type for %v", t)
 	}
 }
 
 // resolveSingleton resolves a singleton dependency (created once and cached)
-func (dc *DependencyContainer) resolveSingleton(t reflect.Type, registration *Registration, scopeID string, stack []reflect.Type) (interface{}, error) {
-	// 1. Fast path: read lock
-	dc.mu.RLock()
-	if dep, exists := dc.dependencies[t]; exists {
-		dc.mu.RUnlock()
-		return dep, nil
-	}
-
-	// 2. Check if another goroutine is already building this
-	waitChan, inProg := dc.inProgress[t]
-	dc.mu.RUnlock()
-
-	if inProg {
-		<-waitChan           // Wait for the builder to finish
-		return dc.Resolve(t) // Recursive call (will hit fast path)
-	}
-
-	// 3. Slow path: become the builder
-	dc.mu.Lock()
-	// Double check
-	if dep, exists := dc.dependencies[t]; exists {
-		dc.mu.Unlock()
-		return dep, nil
-	}
-	if waitChan, inProg = dc.inProgress[t]; inProg {
-		dc.mu.Unlock()
-		<-waitChan
-		return dc.Resolve(t)
-	}
-
-	// Mark as in-progress
-	done := make(chan struct{})
-	dc.inProgress[t] = done
-	dc.mu.Unlock()
-
-	// Ensure we close the channel and cleanup even if constructor panics
-	defer func() {
-		dc.mu.Lock()
-		delete(dc.inProgress, t)
-		close(done)
-		dc.mu.Unlock()
-	}()
-
-	// Create the instance
-	instance, err := registration.constructor(dc, scopeID, stack)
-	if err != nil {
-		return nil, err
-	}
-
-	// Store the created instance
-	dc.mu.Lock()
-	dc.dependencies[t] = instance
-	dc.mu.Unlock()
-
-	return instance, nil
+func (dc *DependencyContainer) resolveSingleton(ctx context.Context, t reflect.Type, registration *Registration, scopeID string, stack []nodeKey) (interface{}, error) {
+	return dc.resolveCached(ctx, instanceKey{t: t}, stack, func() (interface{}, error) {
+		return registration.constructor(ctx, dc, scopeID, stack)
+	})
 }
 
 // resolveTransient resolves a transient dependency (created every time)
-func (dc *DependencyContainer) resolveTransient(t reflect.Type, registration *Registration, scopeID string, stack []reflect.Type) (interface{}, error) {
+func (dc *DependencyContainer) resolveTransient(ctx context.Context, t reflect.Type, registration *Registration, scopeID string, stack []nodeKey) (interface{}, error) {
 	// Create the instance (no caching needed, cycle detection already done in resolveWithScope)
-	return registration.constructor(dc, scopeID, stack)
+	return registration.constructor(ctx, dc, scopeID, stack)
 }
 
 // resolveScoped resolves a scoped dependency (created once per scope context)
-func (dc *DependencyContainer) resolveScoped(t reflect.Type, registration *Registration, scopeID string, stack []reflect.Type) (interface{}, error) {
+func (dc *DependencyContainer) resolveScoped(ctx context.Context, t reflect.Type, registration *Registration, scopeID string, stack []nodeKey) (interface{}, error) {
 	if scopeID == "" {
-		return nil, fmt.Errorf("scope ID required for scoped dependency %v", t)
+		return nil, &ScopeRequiredError{Type: t}
 	}
 
-	dc.mu.RLock()
-	if scopeCache, exists := dc.scopedInstances[scopeID]; exists {
-		if dep, exists := scopeCache[t]; exists {
+	return dc.resolveCached(ctx, instanceKey{scopeID: scopeID, t: t}, stack, func() (interface{}, error) {
+		return registration.constructor(ctx, dc, scopeID, stack)
+	})
+}
+
+// flight is an in-progress build of a cached instance. Its outcome is shared with every
+// caller that asked for the same instance meanwhile.
+type flight struct {
+	done      chan struct{}
+	instance  interface{}
+	err       error
+	abandoned bool // The builder's context ended; waiters must not take its error as their own
+}
+
+// resolveCached returns the cached instance for key, building it with construct on a miss.
+// Only one goroutine builds a given key at a time, and construction never happens under dc.mu,
+// so constructors are free to resolve their own dependencies (including other keys in the same scope).
+// Callers arriving during a build wait for it and share its result, instance or error; waiting is
+// abandoned when ctx is done.
+func (dc *DependencyContainer) resolveCached(ctx context.Context, key instanceKey, stack []nodeKey, construct func() (interface{}, error)) (interface{}, error) {
+	for {
+		// 1. Fast path: read lock
+		dc.mu.RLock()
+		if dep, exists := dc.lookupInstance(key); exists {
 			dc.mu.RUnlock()
 			return dep, nil
 		}
-	}
-	dc.mu.RUnlock()
-
-	dc.mu.Lock()
-	defer dc.mu.Unlock()
+		if err, failed := dc.failures[key]; failed {
+			dc.mu.RUnlock()
+			return nil, err
+		}
+		dc.mu.RUnlock()
 
-	// Double check
-	if scopeCache, exists := dc.scopedInstances[scopeID]; exists {
-		if dep, exists := scopeCache[t]; exists {
+		// 2. Slow path: either wait for the current builder or become the builder
+		dc.mu.Lock()
+		// Double check
+		if dep, exists := dc.lookupInstance(key); exists {
+			dc.mu.Unlock()
 			return dep, nil
 		}
-	} else {
-		dc.scopedInstances[scopeID] = make(map[reflect.Type]interface{})
-	}
+		if err, failed := dc.failures[key]; failed {
+			dc.mu.Unlock()
+			return nil, err
+		}
+		if f, inProg := dc.inProgress[key]; inProg {
+			dc.mu.Unlock()
+			select {
+			case <-f.done:
+				if f.abandoned {
+					// Nothing was built for us; try again with our own context
+					continue
+				}
+				return f.instance, f.err
+			case <-ctx.Done():
+				return nil, contextError(ctx, stack)
+			}
+		}
 
-	// Create the instance
-	// Note: Scoped creators don't wait for each other across different scopeIDs.
-	// Within the same scopeID, they are protected by dc.mu.
-	instance, err := registration.constructor(dc, scopeID, stack)
-	if err != nil {
-		return nil, err
-	}
+		if key.scopeID != "" && !dc.beginScopeBuild(key.scopeID) {
+			dc.mu.Unlock()
+			return nil, &ScopeNotFoundError{ScopeID: key.scopeID, Type: key.t}
+		}
 
-	dc.scopedInstances[scopeID][t] = instance
-	return instance, nil
-}
+		// Mark as in-progress
+		f := &flight{done: make(chan struct{})}
+		dc.inProgress[key] = f
+		dc.mu.Unlock()
 
-func (dc *DependencyContainer) formatDependencyChain(circularType reflect.Type, stack []reflect.Type) string {
-	if len(stack) == 0 {
-		return fmt.Sprintf("%v -> %v (circular)", circularType, circularType)
+		return dc.build(ctx, key, f, stack, construct)
 	}
+}
+
+// build runs construct for an in-progress key under the failure policy, caches the result and
+// publishes it to the waiters
+func (dc *DependencyContainer) build(ctx context.Context, key instanceKey, f *flight, stack []nodeKey, construct func() (interface{}, error)) (interface{}, error) {
+	// Waiters must not see a nil instance without an error if the constructor panics through us
+	f.err = fmt.Errorf("building %v panicked", key.t)
+
+	// Ensure we close the channel and cleanup even if constructor panics
+	defer func() {
+		dc.mu.Lock()
+		delete(dc.inProgress, key)
+		close(f.done)
+		dc.mu.Unlock()
+		if key.scopeID != "" {
+			dc.endScopeBuild(key.scopeID)
+		}
+	}()
 
-	chain := ""
-	for i, t := range stack {
-		if i > 0 {
-			chain += " -> "
+	f.instance, f.err = dc.attempt(ctx, stack, construct)
+
+	dc.mu.Lock()
+	switch {
+	case f.err == nil && key.scopeID != "" && dc.scopeDestroyed(key.scopeID):
+		// The scope was destroyed while we were building: caching the instance would
+		// revive the scope, and nothing would ever close it
+		dc.mu.Unlock()
+		f.err = &ScopeNotFoundError{ScopeID: key.scopeID, Type: key.t}
+		if err := dispose(context.Background(), []interface{}{f.instance}); err != nil {
+			f.err = errors.Join(f.err, err)
 		}
-		chain += t.String()
+		f.instance = nil
+		return nil, f.err
+	case f.err == nil:
+		dc.storeInstance(key, f.instance)
+	case ctx.Err() != nil:
+		f.abandoned = true
+	case dc.failurePolicy.Mode == CacheFailure:
+		if dc.failures == nil {
+			dc.failures = make(map[instanceKey]error)
+		}
+		dc.failures[key] = f.err
 	}
-	chain += " -> " + circularType.String()
+	dc.mu.Unlock()
 
-	return chain
+	return f.instance, f.err
 }
@@ -1,37 +1,260 @@
 package container
 
 import (
+	"context"
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
+	"fmt"
 	"reflect"
 )
 
-// CreateScope creates a new scope context and returns its ID
+// CreateScope creates a new scope context and returns its ID.
+// Ancestors build scoped instances for the scope too, so it is created in each of them.
 func (dc *DependencyContainer) CreateScope() string {
+	scopeID := generateScopeID()
+	for c := dc; c != nil; c = c.parent {
+		c.mu.Lock()
+		if c.scopedInstances[scopeID] == nil {
+			c.scopedInstances[scopeID] = make(map[reflect.Type]interface{})
+		}
+		c.mu.Unlock()
+	}
+	return scopeID
+}
+
+// root returns the container at the top of dc's hierarchy
+func (dc *DependencyContainer) root() *DependencyContainer {
+	for dc.parent != nil {
+		dc = dc.parent
+	}
+	return dc
+}
+
+// beginScopeBuild records a build for scopeID, unless the scope was destroyed while earlier builds
+// for it were still running: their instances are discarded, and so must be anything built for them.
+// Scope IDs that were never created with CreateScope are accepted.
+func (dc *DependencyContainer) beginScopeBuild(scopeID string) bool {
+	root := dc.root()
+	root.scopeMu.Lock()
+	defer root.scopeMu.Unlock()
+
+	if _, destroyed := root.destroyedScopes[scopeID]; destroyed {
+		return false
+	}
+	if root.scopeBuilds == nil {
+		root.scopeBuilds = make(map[string]int)
+	}
+	root.scopeBuilds[scopeID]++
+	return true
+}
+
+// endScopeBuild releases a build recorded by beginScopeBuild. Once the last build of a destroyed
+// scope is done, the scope ID can be used again.
+func (dc *DependencyContainer) endScopeBuild(scopeID string) {
+	root := dc.root()
+	root.scopeMu.Lock()
+	defer root.scopeMu.Unlock()
+
+	if root.scopeBuilds[scopeID]--; root.scopeBuilds[scopeID] <= 0 {
+		delete(root.scopeBuilds, scopeID)
+		delete(root.destroyedScopes, scopeID)
+	}
+}
+
+// scopeDestroyed reports whether scopeID was destroyed while builds for it were in progress
+func (dc *DependencyContainer) scopeDestroyed(scopeID string) bool {
+	root := dc.root()
+	root.scopeMu.Lock()
+	defer root.scopeMu.Unlock()
+
+	_, destroyed := root.destroyedScopes[scopeID]
+	return destroyed
+}
+
+// markScopeDestroyed makes the builds in progress for scopeID discard their instances
+func (dc *DependencyContainer) markScopeDestroyed(scopeID string) {
+	root := dc.root()
+	root.scopeMu.Lock()
+	defer root.scopeMu.Unlock()
+
+	if root.scopeBuilds[scopeID] == 0 {
+		return
+	}
+	if root.destroyedScopes == nil {
+		root.destroyedScopes = make(map[string]struct{})
+	}
+	root.destroyedScopes[scopeID] = struct{}{}
+}
+
+// DestroyScope removes a scope and its instances (including named ones) from every container
+// in dc's hierarchy. Instances implementing Disposer or io.Closer are closed in reverse creation
+// order, those of child containers before those of their parents.
+func (dc *DependencyContainer) DestroyScope(scopeID string) error {
+	return dc.destroyScope(context.Background(), scopeID)
+}
+
+// DestroyAllScopes removes all active scope contexts and closes their instances
+func (dc *DependencyContainer) DestroyAllScopes() error {
+	return dc.destroyAllScopes(context.Background())
+}
+
+// DeclareScopedValue registers t as a Scoped value that is supplied to each scope with
+// SupplyScopedValue rather than constructed. Declaring the same type again is a no-op, but
+// like any registration, a declaration cannot take the place of an existing constructor or value.
+func (dc *DependencyContainer) DeclareScopedValue(t reflect.Type) error {
 	dc.mu.Lock()
 	defer dc.mu.Unlock()
 
-	scopeID := generateScopeID()
-	dc.scopedInstances[scopeID] = make(map[reflect.Type]interface{})
-	return scopeID
+	if registration, exists := dc.constructors[t]; exists && registration.scopedValue {
+		return nil
+	}
+	registration := &Registration{
+		constructor: func(ctx context.Context, container *DependencyContainer, scopeID string, stack []nodeKey) (interface{}, error) {
+			return nil, fmt.Errorf("no %v was supplied to scope %s", t, scopeID)
+		},
+		scope:       Scoped,
+		scopedValue: true,
+		caller:      CallerLocation(),
+	}
+	if err := dc.checkDuplicate("", t, registration); err != nil {
+		return err
+	}
+	dc.storeRegistration("", t, registration)
+	return nil
 }
 
-// DestroyScope removes a scope and its instances (including named ones)
-func (dc *DependencyContainer) DestroyScope(scopeID string) {
+// SupplyScopedValue stores instance as the value of a declared scoped type within a scope.
+// The value is supplied to every container in the hierarchy that declares t, so inherited
+// constructors see it too. Like other supplied values, it is not closed when the scope is destroyed.
+func (dc *DependencyContainer) SupplyScopedValue(scopeID string, t reflect.Type, instance interface{}) error {
+	if err := checkInstance(t, instance); err != nil {
+		return err
+	}
+
 	dc.mu.Lock()
 	defer dc.mu.Unlock()
 
+	if _, exists := dc.scopedInstances[scopeID]; !exists {
+		return fmt.Errorf("scope %s does not exist", scopeID)
+	}
+	declared := dc.supplyScopedValue(scopeID, t, instance)
+	for p := dc.parent; p != nil; p = p.parent {
+		p.mu.Lock()
+		declared = p.supplyScopedValue(scopeID, t, instance) || declared
+		p.mu.Unlock()
+	}
+	if !declared {
+		return fmt.Errorf("type %v is not declared as a scoped value", t)
+	}
+	return nil
+}
+
+// supplyScopedValue stores instance in the scope if dc declares t as a scoped value.
+// Callers must hold dc.mu (write).
+func (dc *DependencyContainer) supplyScopedValue(scopeID string, t reflect.Type, instance interface{}) bool {
+	if registration, exists := dc.constructors[t]; !exists || !registration.scopedValue {
+		return false
+	}
+	// Not stored with storeInstance: supplied values are never disposed
+	if dc.scopedInstances[scopeID] == nil {
+		dc.scopedInstances[scopeID] = make(map[reflect.Type]interface{})
+	}
+	dc.scopedInstances[scopeID][t] = instance
+	// Builds in the scope that failed for want of the value may succeed now
+	for key := range dc.failures {
+		if key.scopeID == scopeID {
+			delete(dc.failures, key)
+		}
+	}
+	return true
+}
+
+func (dc *DependencyContainer) destroyScope(ctx context.Context, scopeID string) error {
+	// Every container of the hierarchy may cache instances for the scope under the same ID
+	root := dc.root()
+	root.markScopeDestroyed(scopeID)
+	return root.destroyScopeTree(ctx, scopeID)
+}
+
+// destroyScopeTree destroys a scope in dc's descendants, then in dc itself
+func (dc *DependencyContainer) destroyScopeTree(ctx context.Context, scopeID string) error {
+	dc.mu.RLock()
+	children := append([]*DependencyContainer(nil), dc.children...)
+	dc.mu.RUnlock()
+
+	var errs []error
+	for i := len(children) - 1; i >= 0; i-- {
+		errs = append(errs, children[i].destroyScopeTree(ctx, scopeID))
+	}
+	return errors.Join(append(errs, dc.destroyLocalScope(ctx, scopeID))...)
+}
+
+// destroyLocalScope removes a scope from dc alone and closes dc's instances for it
+func (dc *DependencyContainer) destroyLocalScope(ctx context.Context, scopeID string) error {
+	dc.mu.Lock()
+	disposables := dc.scopedDisposables[scopeID]
 	delete(dc.scopedInstances, scopeID)
 	delete(dc.namedScopedInstances, scopeID)
+	delete(dc.scopedDisposables, scopeID)
+	for key := range dc.groupInstances {
+		if key.scopeID == scopeID {
+			delete(dc.groupInstances, key)
+		}
+	}
+	for key := range dc.failures {
+		if key.scopeID == scopeID {
+			delete(dc.failures, key)
+		}
+	}
+	dc.mu.Unlock()
+
+	if err := dispose(ctx, disposables); err != nil {
+		return fmt.Errorf("failed to destroy scope %s: %w", scopeID, err)
+	}
+	return nil
 }
 
-// DestroyAllScopes removes all active scope contexts and their instances
-func (dc *DependencyContainer) DestroyAllScopes() {
-	dc.mu.Lock()
-	defer dc.mu.Unlock()
+// destroyAllScopes destroys every scope with instances in dc or its descendants
+func (dc *DependencyContainer) destroyAllScopes(ctx context.Context) error {
+	scopeIDs := make(map[string]bool)
+	dc.collectScopeIDs(scopeIDs)
 
-	dc.scopedInstances = make(map[string]map[reflect.Type]interface{})
-	dc.namedScopedInstances = make(map[string]map[string]map[reflect.Type]interface{})
+	var errs []error
+	for scopeID := range scopeIDs {
+		errs = append(errs, dc.destroyScope(ctx, scopeID))
+	}
+	return errors.Join(errs...)
+}
+
+// collectScopeIDs adds the IDs of the scopes dc or its descendants hold anything for
+func (dc *DependencyContainer) collectScopeIDs(scopeIDs map[string]bool) {
+	dc.mu.RLock()
+	for scopeID := range dc.scopedInstances {
+		scopeIDs[scopeID] = true
+	}
+	for scopeID := range dc.namedScopedInstances {
+		scopeIDs[scopeID] = true
+	}
+	for scopeID := range dc.scopedDisposables {
+		scopeIDs[scopeID] = true
+	}
+	for key := range dc.groupInstances {
+		if key.scopeID != "" {
+			scopeIDs[key.scopeID] = true
+		}
+	}
+	for key := range dc.failures {
+		if key.scopeID != "" {
+			scopeIDs[key.scopeID] = true
+		}
+	}
+	children := append([]*DependencyContainer(nil), dc.children...)
+	dc.mu.RUnlock()
+
+	for _, child := range children {
+		child.collectScopeIDs(scopeIDs)
+	}
 }
 
 // generateScopeID generates a unique scope identifier
@@ -3,109 +3,291 @@ package container
 import (
 	"fmt"
 	"reflect"
+	"strings"
 )
 
-type nodeKey struct {
-	t    reflect.Type
-	name string
+// Validate eagerly checks the entire dependency graph for missing dependencies and circular dependencies.
+// It returns a *ValidationReport listing every problem, or nil if the graph is valid.
+func (dc *DependencyContainer) Validate() error {
+	if report := dc.ValidationReport(); !report.Valid() {
+		return report
+	}
+	return nil
 }
 
-// Validate eagerly checks the entire dependency graph for missing dependencies and circular dependencies
-func (dc *DependencyContainer) Validate() error {
+// ValidationReport checks the entire dependency graph and collects every problem instead of stopping at the first
+func (dc *DependencyContainer) ValidationReport() *ValidationReport {
 	dc.mu.RLock()
 	defer dc.mu.RUnlock()
 
-	visited := make(map[nodeKey]bool)
+	v := &validator{
+		dc:         dc,
+		report:     &ValidationReport{},
+		visited:    make(map[nodeKey]bool),
+		inProgress: make(map[nodeKey]bool),
+		unresolved: make(map[nodeKey]bool),
+		missing:    make(map[nodeKey]*MissingDependencyError),
+		bindings:   make(map[bindingRef]*InvalidBinding),
+		cycles:     make(map[string]bool),
+		locations:  make(map[string]string),
+	}
 
 	// Check unnamed constructors
 	for t := range dc.constructors {
-		if err := dc.validateNode(nodeKey{t: t}, visited, make(map[nodeKey]bool), nil); err != nil {
-			return err
-		}
+		v.visit(nodeKey{t: t}, nil, "")
 	}
 
 	// Check named constructors
 	for name, nameMap := range dc.namedConstructors {
 		for t := range nameMap {
-			if err := dc.validateNode(nodeKey{t: t, name: name}, visited, make(map[nodeKey]bool), nil); err != nil {
-				return err
-			}
+			v.visit(nodeKey{t: t, name: name}, nil, "")
 		}
 	}
 
-	return nil
-}
+	// Check every value group member
+	for group, members := range dc.groups {
+		for i := range members {
+			v.visit(dc.groupMemberKey(group, i), nil, "")
+		}
+	}
 
-func (dc *DependencyContainer) validateNode(key nodeKey, visited, inProgress map[nodeKey]bool, stack []nodeKey) error {
-	if inProgress[key] {
-		chain := ""
-		for _, node := range stack {
-			if node.name != "" {
-				chain += fmt.Sprintf("[%s]%v -> ", node.name, node.t)
-			} else {
-				chain += fmt.Sprintf("%v -> ", node.t)
-			}
+	// Check every decorated type, including interfaces only reachable through a binding
+	for t, decorators := range dc.decorators {
+		for _, d := range decorators {
+			v.visit(nodeKey{t: t}, nil, "decorator "+d.fnType.String())
 		}
-		if key.name != "" {
-			chain += fmt.Sprintf("[%s]%v", key.name, key.t)
-		} else {
-			chain += fmt.Sprintf("%v", key.t)
+	}
+
+	// Check bindings nobody depends on yet
+	v.checkBindings()
+
+	// Check singletons for shorter-lived dependencies
+	v.checkCaptives()
+
+	// A child resolves through its ancestors, so their problems are its problems too
+	if dc.parent != nil {
+		v.report.merge(dc.parent.ValidationReport())
+	}
+
+	v.report.sort()
+	return v.report
+}
+
+// bindingRef identifies an interface binding (unnamed when name is empty)
+type bindingRef struct {
+	name  string
+	iface reflect.Type
+}
+
+// validator walks the dependency graph once, collecting problems into a report
+type validator struct {
+	dc         *DependencyContainer
+	report     *ValidationReport
+	visited    map[nodeKey]bool
+	inProgress map[nodeKey]bool
+	unresolved map[nodeKey]bool
+	missing    map[nodeKey]*MissingDependencyError
+	bindings   map[bindingRef]*InvalidBinding
+	cycles     map[string]bool
+	locations  map[string]string // Where each visited node is registered, shared by the reported errors
+}
+
+// visit validates a node and everything it depends on. consumer is the node that needs it ("" for roots).
+func (v *validator) visit(key nodeKey, stack []nodeKey, consumer string) {
+	key, via := v.redirect(key)
+
+	if v.inProgress[key] {
+		v.addCycle(key, stack)
+		return
+	}
+	if v.visited[key] {
+		if v.unresolved[key] {
+			v.addMissing(key, via, consumer)
 		}
-		return fmt.Errorf("circular dependency detected: %s", chain)
+		return
 	}
+	v.visited[key] = true
 
-	if visited[key] {
-		return nil
+	var reg *Registration
+	if key.group != "" && key.index == 0 {
+		var err error
+		if reg, err = v.dc.groupRegistration(key); err != nil {
+			v.report.Other = append(v.report.Other, err)
+			return
+		}
+	} else {
+		var owner *DependencyContainer
+		var exists bool
+		if owner, _, reg, exists = v.dc.lookupOwner(key); !exists {
+			v.unresolved[key] = true
+			v.addMissing(key, via, consumer)
+			return
+		}
+		if owner != v.dc {
+			// Inherited nodes are validated with their ancestor's graph
+			return
+		}
 	}
 
-	inProgress[key] = true
+	v.inProgress[key] = true
+	defer func() { v.inProgress[key] = false }()
 	newStack := append(stack, key)
-	defer func() {
-		inProgress[key] = false
-		visited[key] = true
-	}()
+	self := key.String()
+	if location := reg.describe(); location != "" {
+		v.locations[self] = location
+	}
 
-	var reg *Registration
-	var exists bool
-	t := key.t
-
-	if key.name != "" {
-		// Named resolution
-		if t.Kind() == reflect.Interface {
-			concreteType, ok := dc.GetNamedInterfaceBinding(key.name, t)
-			if ok {
-				return dc.validateNode(nodeKey{t: concreteType}, visited, inProgress, newStack)
+	// Check dependencies (honoring parameter name qualifiers)
+	for _, dep := range v.dc.servedDependencies(reg) {
+		if dep.optional {
+			if _, _, _, ok := v.dc.lookupOwner(dep.key); !ok {
+				continue
 			}
 		}
-		nameMap, ok := dc.namedConstructors[key.name]
-		if ok {
-			reg, exists = nameMap[t]
+		if dep.lazy != nil {
+			// Lazy edges are only followed after construction, so they cannot form cycles;
+			// their targets are validated as roots of their own
+			if _, _, _, ok := v.dc.lookupOwner(dep.key); !ok {
+				target, via := v.redirect(dep.key)
+				v.addMissing(target, via, self)
+			}
+			continue
 		}
-	} else {
-		// Unnamed resolution
-		if t.Kind() == reflect.Interface {
-			concreteType, ok := dc.GetInterfaceBinding(t)
-			if ok {
-				return dc.validateNode(nodeKey{t: concreteType}, visited, inProgress, newStack)
+		v.visit(dep.key, newStack, self)
+	}
+}
+
+// redirect follows interface bindings and the named-to-unnamed fallback to the node that
+// actually serves key, also returning the last binding followed (if any)
+func (v *validator) redirect(key nodeKey) (nodeKey, *bindingRef) {
+	var via *bindingRef
+	for {
+		switch {
+		case key.group != "":
+			return key, via
+		case key.name != "":
+			if key.t.Kind() == reflect.Interface {
+				if concreteType, ok := v.dc.namedInterfaceBindings[key.name][key.t]; ok {
+					via = &bindingRef{name: key.name, iface: key.t}
+					key = nodeKey{t: concreteType}
+					continue
+				}
+			}
+			if v.dc.delegate(key) != nil {
+				return key, via
+			}
+			if _, ok := v.dc.namedConstructors[key.name][key.t]; ok || key.t.Kind() == reflect.Interface {
+				return key, via
+			}
+			// Resolution falls back to the unnamed registration for concrete types
+			key = nodeKey{t: key.t}
+		default:
+			if _, ok := v.dc.decoratedRegistration(key.t); ok {
+				return key, via
+			}
+			if key.t.Kind() == reflect.Interface {
+				if concreteType, ok := v.dc.interfaceBindings[key.t]; ok {
+					via = &bindingRef{iface: key.t}
+					key = nodeKey{t: concreteType}
+					continue
+				}
 			}
-			return fmt.Errorf("no binding found for interface %v", t)
+			return key, via
 		}
-		reg, exists = dc.constructors[t]
 	}
+}
 
-	if !exists {
-		if key.name != "" {
-			return fmt.Errorf("no constructor found for named dependency %v (%s)", t, key.name)
+// addMissing records an unresolvable node (or the binding that leads to it) and its consumer
+func (v *validator) addMissing(key nodeKey, via *bindingRef, consumer string) {
+	if via != nil {
+		if _, ok := v.bindings[*via]; !ok {
+			b := &InvalidBinding{
+				Interface: via.iface,
+				Name:      via.name,
+				Concrete:  key.t,
+				Reason:    fmt.Sprintf("no constructor registered for concrete type %v", key.t),
+				Module:    v.dc.bindingModules[*via],
+			}
+			v.bindings[*via] = b
+			v.report.InvalidBindings = append(v.report.InvalidBindings, b)
 		}
-		return fmt.Errorf("no constructor registered for type %v", t)
+	} else if _, ok := v.missing[key]; !ok {
+		m := &MissingDependencyError{Type: key.t, Name: key.name, Locations: v.locations}
+		v.missing[key] = m
+		v.report.Missing = append(v.report.Missing, m)
+	}
+	v.addConsumer(key, via, consumer)
+}
+
+// addConsumer notes a consumer of a missing node or invalid binding
+func (v *validator) addConsumer(key nodeKey, via *bindingRef, consumer string) {
+	if consumer == "" {
+		return
+	}
+	if via != nil {
+		b := v.bindings[*via]
+		b.Consumers = appendUnique(b.Consumers, consumer)
+		return
 	}
+	m := v.missing[key]
+	m.RequestedBy = appendUnique(m.RequestedBy, consumer)
+}
 
-	// Check dependencies (all parameters are currently resolved UNNAMED)
-	for _, paramType := range reg.paramTypes {
-		if err := dc.validateNode(nodeKey{t: paramType}, visited, inProgress, newStack); err != nil {
-			return err
+// addCycle records the cycle closed by key, once per distinct cycle
+func (v *validator) addCycle(key nodeKey, stack []nodeKey) {
+	start := 0
+	for i, node := range stack {
+		if node == key {
+			start = i
+			break
 		}
 	}
+	path := make([]string, 0, len(stack)-start+1)
+	for _, node := range stack[start:] {
+		path = append(path, node.String())
+	}
 
-	return nil
+	// The same cycle can be entered from any of its nodes; rotate to a canonical start
+	canonical := 0
+	for i := range path {
+		if path[i] < path[canonical] {
+			canonical = i
+		}
+	}
+	id := strings.Join(append(append([]string{}, path[canonical:]...), path[:canonical]...), " -> ")
+	if v.cycles[id] {
+		return
+	}
+	v.cycles[id] = true
+	v.report.Cycles = append(v.report.Cycles, &CycleError{Path: append(path, key.String()), Locations: v.locations})
+}
+
+// checkBindings reports bindings whose concrete type cannot be built, even without consumers
+func (v *validator) checkBindings() {
+	check := func(ref bindingRef, concreteType reflect.Type) {
+		if _, ok := v.bindings[ref]; ok {
+			return
+		}
+		if _, _, _, ok := v.dc.lookupOwner(nodeKey{t: concreteType}); ok {
+			return
+		}
+		v.addMissing(nodeKey{t: concreteType}, &ref, "")
+	}
+	for iface, concreteType := range v.dc.interfaceBindings {
+		check(bindingRef{iface: iface}, concreteType)
+	}
+	for name, bindings := range v.dc.namedInterfaceBindings {
+		for iface, concreteType := range bindings {
+			check(bindingRef{name: name, iface: iface}, concreteType)
+		}
+	}
+}
+
+func appendUnique(list []string, s string) []string {
+	for _, existing := range list {
+		if existing == s {
+			return list
+		}
+	}
+	return append(list, s)
 }
@@ -0,0 +1,36 @@
+package di
+
+import (
+	"github.com/binodta/depWeaver/internal/container"
+)
+
+// Container is an independent dependency graph.
+// The package-level functions operate on a default Container; use New to
+// create additional, isolated graphs (e.g. per server or per parallel test).
+type Container struct {
+	dc *container.DependencyContainer
+}
+
+var defaultContainer = New()
+
+// New creates an empty Container
+func New() *Container {
+	return &Container{dc: container.New()}
+}
+
+// Child creates a Container layered on top of c, e.g. per tenant or plugin.
+// Its registrations override or extend c's, and anything it does not register is resolved
+// by c. Singletons are owned (built, cached and closed) by the container that registered them.
+func (c *Container) Child() *Container {
+	return &Container{dc: c.dc.NewChild()}
+}
+
+// Default returns the Container used by the package-level functions
+func Default() *Container {
+	return defaultContainer
+}
+
+// Reset clears the default container state (useful for testing)
+func Reset() {
+	defaultContainer = New()
+}
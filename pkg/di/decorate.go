@@ -0,0 +1,21 @@
+package di
+
+// Decorate wraps an already-registered type without touching its constructor. The decorator
+// receives the instance as its first parameter; any further parameters are resolved from
+// the container:
+//
+//	c.Decorate(func(r Repository, log *Logger) Repository {
+//		return &loggingRepository{next: r, log: log}
+//	})
+//
+// Decorators of the same type chain in registration order, and the decorated instance is
+// cached according to the scope of the decorated registration. Like ProvideTo, Decorate does not
+// validate the graph; the decorator's dependencies are checked by Validate.
+func (c *Container) Decorate(decorator interface{}) error {
+	return c.dc.RegisterDecorator(decorator)
+}
+
+// Decorate wraps an already-registered type in the default container
+func Decorate(decorator interface{}) error {
+	return defaultContainer.Decorate(decorator)
+}
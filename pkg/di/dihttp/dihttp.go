@@ -0,0 +1,78 @@
+// Package dihttp provides net/http middleware that gives every request its own di scope.
+//
+//	dihttp.Register(c) // before registering constructors that take the request
+//	...
+//	mux.Handle("/orders", dihttp.Handler[*OrderHandler](nil))
+//	http.ListenAndServe(":8080", dihttp.Middleware(c)(mux))
+//
+// Inside the scope, *http.Request and http.ResponseWriter can be injected into Scoped
+// constructors like any other dependency.
+package dihttp
+
+import (
+	"net/http"
+
+	"github.com/binodta/depWeaver/pkg/di"
+)
+
+// Register declares *http.Request and http.ResponseWriter as scoped values of c, so constructors
+// depending on them pass validation. Middleware calls it too; calling it again is a no-op.
+// It fails if either type already has a constructor or value registered in c.
+func Register(c *di.Container) error {
+	if err := di.DeclareScopedTo[*http.Request](c); err != nil {
+		return err
+	}
+	return di.DeclareScopedTo[http.ResponseWriter](c)
+}
+
+// Middleware creates a scope of c for every request, supplies the request and response writer
+// to it, and destroys the scope (closing its instances) once the wrapped handler returns.
+// Scope options are applied to each request scope, e.g. di.OnDestroyError to log close errors.
+// Like http.ServeMux.Handle, it panics on a setup error: Register failing for c.
+func Middleware(c *di.Container, opts ...di.ScopeOption) func(http.Handler) http.Handler {
+	if err := Register(c); err != nil {
+		panic("dihttp: " + err.Error())
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, release := c.WithScope(r.Context(), opts...)
+			defer release()
+
+			r = r.WithContext(ctx)
+			scopeID, _ := di.ScopeFromContext(ctx)
+			if err := di.SupplyScopedTo[*http.Request](c, scopeID, r); err != nil {
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				return
+			}
+			if err := di.SupplyScopedTo[http.ResponseWriter](c, scopeID, w); err != nil {
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Resolve resolves T within the scope of a request served through Middleware
+func Resolve[T any](r *http.Request) (T, error) {
+	return di.ResolveFromContext[T](r.Context())
+}
+
+// Handler returns a handler that resolves T from the request scope and delegates to it.
+// If resolution fails, onError handles the request; a nil onError responds with 500.
+func Handler[T http.Handler](onError func(http.ResponseWriter, *http.Request, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h, err := Resolve[T](r)
+		if err != nil {
+			if onError != nil {
+				onError(w, r, err)
+				return
+			}
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,46 @@
+package di
+
+import (
+	"github.com/binodta/depWeaver/internal/container"
+)
+
+// Error types returned (possibly wrapped) by Resolve and Validate. Inspect them with errors.As:
+//
+//	var missing *di.MissingDependencyError
+//	if errors.As(err, &missing) {
+//		log.Printf("register a constructor for %v (needed by %v)", missing.Type, missing.RequestedBy)
+//	}
+type (
+	// MissingDependencyError reports a dependency that nothing is registered for
+	MissingDependencyError = container.MissingDependencyError
+
+	// CycleError reports a circular dependency
+	CycleError = container.CycleError
+
+	// ConstructorError reports a constructor (or decorator) that returned an error
+	ConstructorError = container.ConstructorError
+
+	// ConstructorPanicError reports a constructor (or decorator) that panicked, with the
+	// panic value, its stack trace and the resolution path
+	ConstructorPanicError = container.ConstructorPanicError
+
+	// DuplicateRegistrationError reports a registration rejected because its type (and name) is taken
+	DuplicateRegistrationError = container.DuplicateRegistrationError
+
+	// ScopeRequiredError reports a scoped dependency resolved without a scope ID
+	ScopeRequiredError = container.ScopeRequiredError
+
+	// ScopeNotFoundError reports a scoped dependency resolved in a scope that does not exist (anymore)
+	ScopeNotFoundError = container.ScopeNotFoundError
+)
+
+// SetCrashOnPanic disables panic recovery. By default a panicking constructor or decorator is
+// reported as a *ConstructorPanicError; with crash set, the panic propagates to the caller.
+func (c *Container) SetCrashOnPanic(crash bool) {
+	c.dc.SetCrashOnPanic(crash)
+}
+
+// SetCrashOnPanic disables panic recovery in the default container
+func SetCrashOnPanic(crash bool) {
+	defaultContainer.SetCrashOnPanic(crash)
+}
@@ -0,0 +1,37 @@
+package di
+
+import (
+	"time"
+
+	"github.com/binodta/depWeaver/internal/container"
+)
+
+// FailurePolicy decides how failed builds of singleton and scoped instances are handled.
+// Concurrent callers always share the outcome of one build; the policy decides what comes after.
+type FailurePolicy = container.FailurePolicy
+
+// FailureMode selects a FailurePolicy behaviour
+type FailureMode = container.FailureMode
+
+const (
+	RetryOnNextCall  = container.RetryOnNextCall  // The next resolve builds again (default)
+	CacheFailure     = container.CacheFailure     // Later resolves return the cached error
+	RetryWithBackoff = container.RetryWithBackoff // Retry the constructor with exponential backoff
+)
+
+// Backoff returns a policy that retries a failing constructor up to attempts times in total,
+// waiting initial before the first retry and doubling the delay after each one.
+// Only the failing constructor is retried, not the constructors depending on it.
+func Backoff(attempts int, initial time.Duration) FailurePolicy {
+	return FailurePolicy{Mode: RetryWithBackoff, Attempts: attempts, Backoff: initial}
+}
+
+// SetFailurePolicy sets how failed builds are handled. Registering anything forgets cached failures.
+func (c *Container) SetFailurePolicy(policy FailurePolicy) {
+	c.dc.SetFailurePolicy(policy)
+}
+
+// SetFailurePolicy sets the failure policy of the default container
+func SetFailurePolicy(policy FailurePolicy) {
+	defaultContainer.SetFailurePolicy(policy)
+}
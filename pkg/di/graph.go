@@ -0,0 +1,31 @@
+package di
+
+import (
+	"github.com/binodta/depWeaver/internal/container"
+)
+
+// Graph is a snapshot of the dependency graph with DOT, Mermaid and JSON exporters
+type Graph = container.Graph
+
+// GraphNode is one node of the dependency graph
+type GraphNode = container.GraphNode
+
+// GraphEdge points from a node to a node it needs
+type GraphEdge = container.GraphEdge
+
+// EdgeKind describes how one node reaches another
+type EdgeKind = container.EdgeKind
+
+const (
+	EdgeDependency = container.EdgeDependency // Constructor parameter
+	EdgeOptional   = container.EdgeOptional   // Parameter injected only if registered
+	EdgeLazy       = container.EdgeLazy       // Provider or func parameter resolved on demand
+	EdgeBinding    = container.EdgeBinding    // Interface binding or fallback to the unnamed registration
+)
+
+// Graph returns a snapshot of the container's dependency graph:
+//
+//	os.WriteFile("deps.dot", []byte(c.Graph().DOT()), 0o644)
+func (c *Container) Graph() *Graph {
+	return c.dc.Graph()
+}
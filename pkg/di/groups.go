@@ -0,0 +1,49 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ProvideToGroup adds a constructor to a value group in the given container. Like ProvideTo, it does
+// not validate the graph. Members are injected, in registration order, into di.In fields tagged `group:"<name>"`.
+func ProvideToGroup(c *Container, group string, constructor interface{}, opts ...ProvideOption) error {
+	cfg := newProvideConfig(opts)
+	cfg.opts.Group = group
+	return c.dc.RegisterConstructorWithOptions(constructor, cfg.scope, cfg.opts)
+}
+
+// ResolveGroupScopedFrom resolves every member of a value group within a scope of the given container.
+// Scoped members are created once per scope.
+// @Param scopeID - scope context identifier
+func ResolveGroupScopedFrom[T any](c *Container, group string, scopeID string) ([]T, error) {
+	t := reflect.TypeOf((*[]T)(nil)).Elem()
+
+	instance, err := c.dc.ResolveGroup(group, t, scopeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve value group %q as %v: %w", group, t, err)
+	}
+
+	members, ok := instance.([]T)
+	if !ok {
+		return nil, fmt.Errorf("failed to cast resolved value group to type %v", t)
+	}
+
+	return members, nil
+}
+
+// ResolveGroupFrom resolves every member of a value group from the given container
+func ResolveGroupFrom[T any](c *Container, group string) ([]T, error) {
+	return ResolveGroupScopedFrom[T](c, group, "")
+}
+
+// ResolveGroup resolves every member of a value group, in registration order
+func ResolveGroup[T any](group string) ([]T, error) {
+	return ResolveGroupFrom[T](defaultContainer, group)
+}
+
+// ResolveGroupScoped resolves every member of a value group within a specific scope
+// @Param scopeID - scope context identifier
+func ResolveGroupScoped[T any](group string, scopeID string) ([]T, error) {
+	return ResolveGroupScopedFrom[T](defaultContainer, group, scopeID)
+}
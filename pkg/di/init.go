@@ -6,106 +6,162 @@ import (
 	"github.com/binodta/depWeaver/internal/container"
 )
 
-var dependencyContainer = container.New()
-
 // ScopeRegistration holds a constructor and its scope
 type ScopeRegistration struct {
 	Constructor interface{}
-	Scope       container.Scope
+	Scope       Scope
 }
 
 // Init Register all constructors with Singleton scope (backward compatible)
-func Init(constructors []interface{}) error {
+func (c *Container) Init(constructors []interface{}) error {
 	for _, constructor := range constructors {
-		if err := dependencyContainer.RegisterConstructor(constructor); err != nil {
+		if err := c.dc.RegisterConstructor(constructor); err != nil {
 			return err
 		}
 	}
-	return Validate()
+	return c.Validate()
 }
 
 // MustInit registers constructors and immediately validates the graph, crashing on error
-func MustInit(constructors []interface{}) {
-	if err := Init(constructors); err != nil {
+func (c *Container) MustInit(constructors []interface{}) {
+	if err := c.Init(constructors); err != nil {
 		log.Fatalf("Dependency graph initialization failed: %v", err)
 	}
 }
 
 // InitWithScope registers constructors with specific scopes
-func InitWithScope(registrations []ScopeRegistration) error {
+func (c *Container) InitWithScope(registrations []ScopeRegistration) error {
 	for _, reg := range registrations {
-		if err := dependencyContainer.RegisterConstructorWithScope(reg.Constructor, reg.Scope); err != nil {
+		if err := c.dc.RegisterConstructorWithScope(reg.Constructor, reg.Scope); err != nil {
 			return err
 		}
 	}
-	return Validate()
+	return c.Validate()
 }
 
 // MustInitWithScope registers constructors with scopes and immediately validates the graph, crashing on error
-func MustInitWithScope(registrations []ScopeRegistration) {
-	if err := InitWithScope(registrations); err != nil {
+func (c *Container) MustInitWithScope(registrations []ScopeRegistration) {
+	if err := c.InitWithScope(registrations); err != nil {
 		log.Fatalf("Dependency graph initialization failed: %v", err)
 	}
 }
 
 // RegisterRuntime allows runtime registration of constructors after initialization
-func RegisterRuntime(constructor interface{}, scope container.Scope) error {
-	if err := dependencyContainer.RegisterRuntimeConstructor(constructor, scope); err != nil {
+func (c *Container) RegisterRuntime(constructor interface{}, scope Scope) error {
+	if err := c.dc.RegisterRuntimeConstructor(constructor, scope); err != nil {
 		return err
 	}
-	return Validate()
+	return c.Validate()
 }
 
 // RegisterRuntimeBatch allows runtime registration of multiple constructors after initialization
-func RegisterRuntimeBatch(constructors []interface{}, scope container.Scope) error {
+func (c *Container) RegisterRuntimeBatch(constructors []interface{}, scope Scope) error {
 	for _, constructor := range constructors {
-		if err := dependencyContainer.RegisterRuntimeConstructor(constructor, scope); err != nil {
+		if err := c.dc.RegisterRuntimeConstructor(constructor, scope); err != nil {
 			return err
 		}
 	}
-	return Validate()
+	return c.Validate()
 }
 
 // RegisterRuntimeWithScopes registers multiple constructors with individual scopes at runtime
-func RegisterRuntimeWithScopes(registrations []ScopeRegistration) error {
+func (c *Container) RegisterRuntimeWithScopes(registrations []ScopeRegistration) error {
 	for _, reg := range registrations {
-		if err := dependencyContainer.RegisterRuntimeConstructor(reg.Constructor, reg.Scope); err != nil {
+		if err := c.dc.RegisterRuntimeConstructor(reg.Constructor, reg.Scope); err != nil {
 			return err
 		}
 	}
-	return Validate()
+	return c.Validate()
 }
 
 // RegisterNamedConstructor registers a constructor with a specific name and scope
-func RegisterNamedConstructor(name string, constructor interface{}, scope container.Scope) error {
-	if err := dependencyContainer.RegisterNamedConstructorWithScope(name, constructor, scope); err != nil {
+func (c *Container) RegisterNamedConstructor(name string, constructor interface{}, scope Scope) error {
+	if err := c.dc.RegisterNamedConstructorWithScope(name, constructor, scope); err != nil {
 		return err
 	}
-	return Validate()
+	return c.Validate()
 }
 
 // Override replaces an existing constructor and clears any cached instances
-func Override(constructor interface{}, scope container.Scope) error {
-	if err := dependencyContainer.OverrideConstructor(constructor, scope); err != nil {
+func (c *Container) Override(constructor interface{}, scope Scope) error {
+	if err := c.dc.OverrideConstructor(constructor, scope); err != nil {
 		return err
 	}
-	return Validate()
+	return c.Validate()
 }
 
 // OverrideNamed replaces an existing named constructor and clears any cached instances
-func OverrideNamed(name string, constructor interface{}, scope container.Scope) error {
-	if err := dependencyContainer.RegisterNamedConstructorWithScope(name, constructor, scope); err != nil {
+func (c *Container) OverrideNamed(name string, constructor interface{}, scope Scope) error {
+	if err := c.dc.RegisterConstructorWithOptions(constructor, scope, container.RegistrationOptions{Name: name, Replace: true}); err != nil {
 		return err
 	}
-	return Validate()
+	return c.Validate()
 }
 
 // Validate eagerly checks the dependency graph for missing registrations or cycles
-func Validate() error {
-	return dependencyContainer.Validate()
+func (c *Container) Validate() error {
+	return c.dc.Validate()
+}
+
+// ProvideTo registers a constructor in the given container. Without options the constructor is an
+// unnamed Singleton. Only the registration itself is checked, so constructors can be provided in any
+// order; call Validate once everything is registered.
+func ProvideTo(c *Container, constructor interface{}, opts ...ProvideOption) error {
+	cfg := newProvideConfig(opts)
+	return c.dc.RegisterConstructorWithOptions(constructor, cfg.scope, cfg.opts)
+}
+
+// Init Register all constructors with Singleton scope (backward compatible)
+func Init(constructors []interface{}) error {
+	return defaultContainer.Init(constructors)
+}
+
+// MustInit registers constructors and immediately validates the graph, crashing on error
+func MustInit(constructors []interface{}) {
+	defaultContainer.MustInit(constructors)
+}
+
+// InitWithScope registers constructors with specific scopes
+func InitWithScope(registrations []ScopeRegistration) error {
+	return defaultContainer.InitWithScope(registrations)
+}
+
+// MustInitWithScope registers constructors with scopes and immediately validates the graph, crashing on error
+func MustInitWithScope(registrations []ScopeRegistration) {
+	defaultContainer.MustInitWithScope(registrations)
+}
+
+// RegisterRuntime allows runtime registration of constructors after initialization
+func RegisterRuntime(constructor interface{}, scope Scope) error {
+	return defaultContainer.RegisterRuntime(constructor, scope)
+}
+
+// RegisterRuntimeBatch allows runtime registration of multiple constructors after initialization
+func RegisterRuntimeBatch(constructors []interface{}, scope Scope) error {
+	return defaultContainer.RegisterRuntimeBatch(constructors, scope)
+}
+
+// RegisterRuntimeWithScopes registers multiple constructors with individual scopes at runtime
+func RegisterRuntimeWithScopes(registrations []ScopeRegistration) error {
+	return defaultContainer.RegisterRuntimeWithScopes(registrations)
 }
 
-// Reset clears the container state (useful for testing)
-func Reset() {
-	dependencyContainer = container.New()
+// RegisterNamedConstructor registers a constructor with a specific name and scope
+func RegisterNamedConstructor(name string, constructor interface{}, scope Scope) error {
+	return defaultContainer.RegisterNamedConstructor(name, constructor, scope)
+}
+
+// Override replaces an existing constructor and clears any cached instances
+func Override(constructor interface{}, scope Scope) error {
+	return defaultContainer.Override(constructor, scope)
+}
+
+// OverrideNamed replaces an existing named constructor and clears any cached instances
+func OverrideNamed(name string, constructor interface{}, scope Scope) error {
+	return defaultContainer.OverrideNamed(name, constructor, scope)
+}
+
+// Validate eagerly checks the dependency graph for missing registrations or cycles
+func Validate() error {
+	return defaultContainer.Validate()
 }
@@ -1,40 +1,36 @@
 package di
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 )
 
-// BindInterface binds an interface type to a concrete implementation
-func BindInterface[I any, C any]() error {
+// BindInterfaceTo binds an interface type to a concrete implementation in the given container.
+// C must already be registered. Like ProvideTo, it does not validate the rest of the graph.
+func BindInterfaceTo[I any, C any](c *Container) error {
 	interfaceType := reflect.TypeOf((*I)(nil)).Elem()
 	concreteType := reflect.TypeOf((*C)(nil)).Elem()
 
-	if err := dependencyContainer.BindInterface(interfaceType, concreteType); err != nil {
-		return err
-	}
-	return Validate()
+	return c.dc.BindInterface(interfaceType, concreteType)
 }
 
-// BindInterfaceNamed binds an interface type to a concrete implementation with a name
-func BindInterfaceNamed[I any, C any](name string) error {
+// BindInterfaceNamedTo binds an interface type to a concrete implementation with a name in the given container
+func BindInterfaceNamedTo[I any, C any](c *Container, name string) error {
 	interfaceType := reflect.TypeOf((*I)(nil)).Elem()
 	concreteType := reflect.TypeOf((*C)(nil)).Elem()
 
-	if err := dependencyContainer.BindInterfaceNamed(name, interfaceType, concreteType); err != nil {
-		return err
-	}
-	return Validate()
+	return c.dc.BindInterfaceNamed(name, interfaceType, concreteType)
 }
 
-// ResolveNamed resolves a dependency by name (for named interface bindings)
+// ResolveNamedFrom resolves a dependency by name from the given container
 // @Param name - name of the binding
 // @Param T - type to resolve (typically an interface)
-func ResolveNamed[T any](name string) (T, error) {
+func ResolveNamedFrom[T any](c *Container, name string) (T, error) {
 	var zero T
 	t := reflect.TypeOf((*T)(nil)).Elem()
 
-	instance, err := dependencyContainer.ResolveNamed(name, t)
+	instance, err := c.dc.ResolveNamed(name, t)
 	if err != nil {
 		return zero, fmt.Errorf("failed to resolve named type %v with name %q: %w", t, name, err)
 	}
@@ -51,15 +47,15 @@ func ResolveNamed[T any](name string) (T, error) {
 	return castedInstance, nil
 }
 
-// ResolveNamedScoped resolves a named dependency within a specific scope
+// ResolveNamedScopedFrom resolves a named dependency within a specific scope of the given container
 // @Param name - name of the binding
 // @Param scopeID - scope context identifier
 // @Param T - type to resolve (typically an interface)
-func ResolveNamedScoped[T any](name string, scopeID string) (T, error) {
+func ResolveNamedScopedFrom[T any](c *Container, name string, scopeID string) (T, error) {
 	var zero T
 	t := reflect.TypeOf((*T)(nil)).Elem()
 
-	instance, err := dependencyContainer.ResolveNamedWithScope(name, t, scopeID)
+	instance, err := c.dc.ResolveNamedWithScope(name, t, scopeID)
 	if err != nil {
 		return zero, fmt.Errorf("failed to resolve named type %v with name %q in scope %s: %w", t, name, scopeID, err)
 	}
@@ -75,3 +71,73 @@ func ResolveNamedScoped[T any](name string, scopeID string) (T, error) {
 
 	return castedInstance, nil
 }
+
+// ResolveNamedScopedCtxFrom resolves a named dependency within a scope of the given container with a context
+// (see ResolveScopedCtxFrom)
+// @Param name - name of the binding
+// @Param scopeID - scope context identifier
+// @Param T - type to resolve (typically an interface)
+func ResolveNamedScopedCtxFrom[T any](ctx context.Context, c *Container, name string, scopeID string) (T, error) {
+	var zero T
+	t := reflect.TypeOf((*T)(nil)).Elem()
+
+	instance, err := c.dc.ResolveNamedCtx(ctx, name, t, scopeID)
+	if err != nil {
+		return zero, fmt.Errorf("failed to resolve named type %v with name %q: %w", t, name, err)
+	}
+
+	if instance == nil {
+		return zero, fmt.Errorf("resolved instance is nil for type %v with name %q", t, name)
+	}
+
+	castedInstance, ok := instance.(T)
+	if !ok {
+		return zero, fmt.Errorf("failed to cast resolved instance to type %v", t)
+	}
+
+	return castedInstance, nil
+}
+
+// ResolveNamedCtxFrom resolves a named dependency from the given container with a context
+// @Param name - name of the binding
+func ResolveNamedCtxFrom[T any](ctx context.Context, c *Container, name string) (T, error) {
+	return ResolveNamedScopedCtxFrom[T](ctx, c, name, "")
+}
+
+// BindInterface binds an interface type to a concrete implementation
+func BindInterface[I any, C any]() error {
+	return BindInterfaceTo[I, C](defaultContainer)
+}
+
+// BindInterfaceNamed binds an interface type to a concrete implementation with a name
+func BindInterfaceNamed[I any, C any](name string) error {
+	return BindInterfaceNamedTo[I, C](defaultContainer, name)
+}
+
+// ResolveNamed resolves a dependency by name (for named interface bindings)
+// @Param name - name of the binding
+// @Param T - type to resolve (typically an interface)
+func ResolveNamed[T any](name string) (T, error) {
+	return ResolveNamedFrom[T](defaultContainer, name)
+}
+
+// ResolveNamedScoped resolves a named dependency within a specific scope
+// @Param name - name of the binding
+// @Param scopeID - scope context identifier
+// @Param T - type to resolve (typically an interface)
+func ResolveNamedScoped[T any](name string, scopeID string) (T, error) {
+	return ResolveNamedScopedFrom[T](defaultContainer, name, scopeID)
+}
+
+// ResolveNamedCtx resolves a dependency by name with a context (see ResolveCtx)
+// @Param name - name of the binding
+func ResolveNamedCtx[T any](ctx context.Context, name string) (T, error) {
+	return ResolveNamedCtxFrom[T](ctx, defaultContainer, name)
+}
+
+// ResolveNamedScopedCtx resolves a named dependency within a specific scope with a context
+// @Param name - name of the binding
+// @Param scopeID - scope context identifier
+func ResolveNamedScopedCtx[T any](ctx context.Context, name string, scopeID string) (T, error) {
+	return ResolveNamedScopedCtxFrom[T](ctx, defaultContainer, name, scopeID)
+}
@@ -0,0 +1,60 @@
+package di
+
+import (
+	"context"
+	"time"
+
+	"github.com/binodta/depWeaver/internal/container"
+)
+
+// Disposer is implemented by instances that release resources with a context.
+// Instances implementing Disposer or io.Closer are closed by DestroyScope and Shutdown.
+type Disposer = container.Disposer
+
+// Starter is implemented by long-running components started by Start
+type Starter = container.Starter
+
+// Stopper is implemented by long-running components stopped by Stop and Shutdown
+type Stopper = container.Stopper
+
+// Start instantiates all singletons in dependency order and calls Start on each Starter.
+// If one fails, the already-started components are stopped in reverse order.
+func (c *Container) Start(ctx context.Context) error {
+	return c.dc.Start(ctx)
+}
+
+// Stop calls Stop on every started Stopper in reverse startup order
+func (c *Container) Stop(ctx context.Context) error {
+	return c.dc.Stop(ctx)
+}
+
+// SetHookTimeout bounds each individual Start/Stop hook (0 disables the limit)
+func (c *Container) SetHookTimeout(timeout time.Duration) {
+	c.dc.SetHookTimeout(timeout)
+}
+
+// Shutdown stops started components, then closes all scoped instances and all singletons in reverse creation order.
+// Instances implementing io.Closer or Disposer are closed; errors are aggregated.
+func (c *Container) Shutdown(ctx context.Context) error {
+	return c.dc.Shutdown(ctx)
+}
+
+// Shutdown closes all instances owned by the default container
+func Shutdown(ctx context.Context) error {
+	return defaultContainer.Shutdown(ctx)
+}
+
+// Start starts all components of the default container
+func Start(ctx context.Context) error {
+	return defaultContainer.Start(ctx)
+}
+
+// Stop stops all started components of the default container
+func Stop(ctx context.Context) error {
+	return defaultContainer.Stop(ctx)
+}
+
+// SetHookTimeout bounds each individual Start/Stop hook of the default container
+func SetHookTimeout(timeout time.Duration) {
+	defaultContainer.SetHookTimeout(timeout)
+}
@@ -0,0 +1,149 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/binodta/depWeaver/internal/container"
+)
+
+// Bundle is a named, reusable set of registrations created with Module. Packages can publish
+// their wiring as a Bundle for applications to Install:
+//
+//	var StorageModule = di.Module("storage",
+//		di.Provide(NewDB),
+//		di.Provide(NewUserRepo, di.WithLifetime(di.Scoped)),
+//		di.Bind[UserStore, *UserRepo](),
+//		di.Decorator(WithQueryLogging),
+//		di.Include(ConfigModule),
+//	)
+type Bundle struct {
+	name    string
+	options []ModuleOption
+}
+
+// ModuleOption is one entry of a module: a registration, binding, decorator or included module
+type ModuleOption func(*installer)
+
+// Module creates a Bundle. Its name appears in error messages, validation reports and graph exports
+// for everything it registers; included modules are reported by path, e.g. "app/storage".
+func Module(name string, options ...ModuleOption) *Bundle {
+	return &Bundle{name: name, options: options}
+}
+
+// Name returns the name the module was created with
+func (b *Bundle) Name() string {
+	return b.name
+}
+
+// installStep is a deferred registration, tagged with the path of the module it comes from
+type installStep struct {
+	module string
+	apply  func(c *Container, module string) error
+}
+
+// installer collects the steps of every module being installed. Constructors are registered
+// before bindings and decorators, so option order within and across modules does not matter.
+type installer struct {
+	module     string
+	installed  map[*Bundle]bool
+	provides   []installStep
+	bindings   []installStep
+	decorators []installStep
+}
+
+// add queues the steps of a module and its includes. A module included more than once is installed once.
+func (in *installer) add(b *Bundle) {
+	if in.installed[b] {
+		return
+	}
+	in.installed[b] = true
+
+	parent := in.module
+	in.module = b.name
+	if parent != "" {
+		in.module = parent + "/" + b.name
+	}
+	for _, opt := range b.options {
+		opt(in)
+	}
+	in.module = parent
+}
+
+// Provide registers a constructor as part of a module. It accepts the same options as ProvideTo.
+func Provide(constructor interface{}, opts ...ProvideOption) ModuleOption {
+	// Diagnostics point at the module definition rather than the Install call
+	caller := container.CallerLocation()
+	return func(in *installer) {
+		in.provides = append(in.provides, installStep{module: in.module, apply: func(c *Container, module string) error {
+			cfg := newProvideConfig(opts)
+			cfg.opts.Module = module
+			cfg.opts.Caller = caller
+			return c.dc.RegisterConstructorWithOptions(constructor, cfg.scope, cfg.opts)
+		}})
+	}
+}
+
+// Bind binds interface I to the concrete type C as part of a module
+func Bind[I any, C any]() ModuleOption {
+	return BindNamed[I, C]("")
+}
+
+// BindNamed binds interface I to the concrete type C under a name as part of a module
+func BindNamed[I any, C any](name string) ModuleOption {
+	interfaceType := reflect.TypeOf((*I)(nil)).Elem()
+	concreteType := reflect.TypeOf((*C)(nil)).Elem()
+	return func(in *installer) {
+		in.bindings = append(in.bindings, installStep{module: in.module, apply: func(c *Container, module string) error {
+			return c.dc.BindModuleInterface(module, name, interfaceType, concreteType)
+		}})
+	}
+}
+
+// Decorator registers a decorator (see Container.Decorate) as part of a module
+func Decorator(decorator interface{}) ModuleOption {
+	return func(in *installer) {
+		in.decorators = append(in.decorators, installStep{module: in.module, apply: func(c *Container, module string) error {
+			return c.dc.RegisterModuleDecorator(module, decorator)
+		}})
+	}
+}
+
+// Include installs other modules as part of a module
+func Include(modules ...*Bundle) ModuleOption {
+	return func(in *installer) {
+		for _, m := range modules {
+			in.add(m)
+		}
+	}
+}
+
+// Install registers every entry of the given modules and their includes, then validates the graph.
+// Registration errors name the module the failing entry comes from. If an entry or the validation
+// fails, everything registered by the call is undone, so the modules are installed entirely or not at all.
+func (c *Container) Install(modules ...*Bundle) error {
+	in := &installer{installed: make(map[*Bundle]bool)}
+	for _, m := range modules {
+		in.add(m)
+	}
+
+	restore := c.dc.Snapshot()
+	for _, steps := range [][]installStep{in.provides, in.bindings, in.decorators} {
+		for _, step := range steps {
+			if err := step.apply(c, step.module); err != nil {
+				restore()
+				return fmt.Errorf("module %s: %w", step.module, err)
+			}
+		}
+	}
+	if err := c.Validate(); err != nil {
+		restore()
+		return err
+	}
+	return nil
+}
+
+// Install registers the given modules in the default container
+func Install(modules ...*Bundle) error {
+	return defaultContainer.Install(modules...)
+}
@@ -0,0 +1,17 @@
+package di
+
+import (
+	"github.com/binodta/depWeaver/internal/container"
+)
+
+// Optional wraps a constructor parameter (or di.In field) that should only be injected if
+// registered. When nothing is registered, Ok is false and Value is the zero value of T:
+//
+//	func NewServer(cfg *Config, tracer di.Optional[*Tracer]) *Server {
+//		if tracer.Ok { ... tracer.Value ... }
+//	}
+type Optional[T any] struct {
+	marker container.OptionalMarker
+	Value  T
+	Ok     bool
+}
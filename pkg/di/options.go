@@ -0,0 +1,59 @@
+package di
+
+import (
+	"github.com/binodta/depWeaver/internal/container"
+)
+
+// ProvideOption configures a constructor registration made with ProvideTo
+type ProvideOption func(*provideConfig)
+
+type provideConfig struct {
+	scope Scope
+	opts  container.RegistrationOptions
+}
+
+// WithLifetime sets the scope of the registration (Singleton by default)
+func WithLifetime(scope Scope) ProvideOption {
+	return func(cfg *provideConfig) {
+		cfg.scope = scope
+	}
+}
+
+// WithName registers the constructor as a named dependency
+func WithName(name string) ProvideOption {
+	return func(cfg *provideConfig) {
+		cfg.opts.Name = name
+	}
+}
+
+// InGroup adds the constructor to a value group instead of registering it as the type it returns,
+// like ProvideToGroup. It lets modules contribute group members through Provide.
+func InGroup(group string) ProvideOption {
+	return func(cfg *provideConfig) {
+		cfg.opts.Group = group
+	}
+}
+
+// WithParamNames qualifies the constructor's parameters by position.
+// An empty string keeps the parameter unnamed, e.g. WithParamNames("", "replica").
+func WithParamNames(names ...string) ProvideOption {
+	return func(cfg *provideConfig) {
+		cfg.opts.ParamNames = names
+	}
+}
+
+// Replace lets the registration replace an existing one of the same type and name, like Override.
+// Without it, registering a type twice fails with a *DuplicateRegistrationError.
+func Replace() ProvideOption {
+	return func(cfg *provideConfig) {
+		cfg.opts.Replace = true
+	}
+}
+
+func newProvideConfig(opts []ProvideOption) provideConfig {
+	cfg := provideConfig{scope: Singleton}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
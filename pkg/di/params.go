@@ -0,0 +1,27 @@
+package di
+
+import (
+	"github.com/binodta/depWeaver/internal/container"
+)
+
+// In marks a parameter object. Embed it in a struct and take that struct as a
+// constructor parameter to have each exported field resolved from the container:
+//
+//	type ServerParams struct {
+//		di.In
+//		Config  *Config
+//		Replica *sql.DB `name:"replica"`
+//		Tracer  *Tracer `optional:"true"`
+//		Routes  []Route `group:"routes"`
+//	}
+type In = container.In
+
+// Out marks a result object. Embed it in a struct returned by a constructor to
+// register each exported field as its own dependency:
+//
+//	type Stores struct {
+//		di.Out
+//		Users  *UserStore
+//		Orders *OrderStore `name:"orders"`
+//	}
+type Out = container.Out
@@ -0,0 +1,36 @@
+package di
+
+import (
+	"github.com/binodta/depWeaver/internal/container"
+)
+
+// Provider provides lazy access to dependencies.
+// The dependency is not resolved until Get is called.
+type Provider[T any] func() (T, error)
+
+// Get resolves and returns the dependency
+func (p Provider[T]) Get() (T, error) {
+	return p()
+}
+
+// GetProviderFrom returns a provider for lazy resolution from the given container
+// @Param scopeID string - scope context identifier (empty string for default scope)
+func GetProviderFrom[T any](c *Container, scopeID string) Provider[T] {
+	return Provider[T](container.NewProvider[T](c.dc, scopeID, "").Get)
+}
+
+// GetProviderNamedFrom returns a provider for lazy resolution of a named dependency from the given container
+func GetProviderNamedFrom[T any](c *Container, name string) Provider[T] {
+	return Provider[T](container.NewProvider[T](c.dc, "", name).Get)
+}
+
+// GetProvider returns a provider for lazy resolution
+// @Param scopeID string - scope context identifier (empty string for default scope)
+func GetProvider[T any](scopeID string) Provider[T] {
+	return GetProviderFrom[T](defaultContainer, scopeID)
+}
+
+// GetProviderNamed returns a provider for lazy resolution of a named dependency
+func GetProviderNamed[T any](name string) Provider[T] {
+	return GetProviderNamedFrom[T](defaultContainer, name)
+}
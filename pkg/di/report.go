@@ -0,0 +1,48 @@
+package di
+
+import (
+	"github.com/binodta/depWeaver/internal/container"
+)
+
+// ValidationReport lists every problem found in the dependency graph. Validation errors
+// returned by the container are reports; use errors.As to inspect them:
+//
+//	var report *di.ValidationReport
+//	if errors.As(err, &report) {
+//		for _, m := range report.Missing {
+//			fmt.Println(m.Type, "is needed by", m.RequestedBy)
+//		}
+//	}
+type ValidationReport = container.ValidationReport
+
+// InvalidBinding is an interface binding whose concrete type cannot be built
+type InvalidBinding = container.InvalidBinding
+
+// CaptiveDependencyError reports a singleton that depends on a shorter-lived registration
+type CaptiveDependencyError = container.CaptiveDependencyError
+
+// CapturePolicy decides how validation treats a singleton that depends on a transient
+type CapturePolicy = container.CapturePolicy
+
+const (
+	CaptureWarn  = container.CaptureWarn  // Report the edge in ValidationReport.Warnings (default)
+	CaptureAllow = container.CaptureAllow // Do not report the edge
+	CaptureError = container.CaptureError // Fail validation
+)
+
+// SetTransientCapturePolicy sets how validation treats singletons that depend on transients.
+// Singletons depending on scoped registrations always fail validation.
+func (c *Container) SetTransientCapturePolicy(policy CapturePolicy) {
+	c.dc.SetTransientCapturePolicy(policy)
+}
+
+// SetTransientCapturePolicy sets the transient capture policy of the default container
+func SetTransientCapturePolicy(policy CapturePolicy) {
+	defaultContainer.SetTransientCapturePolicy(policy)
+}
+
+// ValidationReport checks the whole dependency graph and returns every problem found.
+// Unlike Validate, it returns a report even when the graph is valid (see ValidationReport.Valid).
+func (c *Container) ValidationReport() *ValidationReport {
+	return c.dc.ValidationReport()
+}
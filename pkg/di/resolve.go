@@ -1,19 +1,18 @@
 package di
 
 import (
+	"context"
 	"fmt"
 	"reflect"
-
-	"github.com/binodta/depWeaver/internal/container"
 )
 
-// Resolve resolves the instance of the given type from the container
-func Resolve[T interface{}]() (T, error) {
+// ResolveFrom resolves the instance of the given type from the given container
+func ResolveFrom[T interface{}](c *Container) (T, error) {
 
 	var zero T
 	t := reflect.TypeOf(&zero).Elem()
 	// Resolve the instance from container
-	instance, err := dependencyContainer.Resolve(t)
+	instance, err := c.dc.Resolve(t)
 	if err != nil {
 		return zero, fmt.Errorf("failed to resolve type %v: %w", t, err)
 	}
@@ -31,12 +30,12 @@ func Resolve[T interface{}]() (T, error) {
 	return castedInstance, nil
 }
 
-// ResolveScoped resolves the instance within a specific scope context
+// ResolveScopedFrom resolves the instance within a specific scope context of the given container
 // @Param scopeID string - scope context identifier
-func ResolveScoped[T interface{}](scopeID string) (T, error) {
+func ResolveScopedFrom[T interface{}](c *Container, scopeID string) (T, error) {
 	var zero T
 	t := reflect.TypeOf(&zero).Elem()
-	instance, err := dependencyContainer.ResolveWithScope(t, scopeID)
+	instance, err := c.dc.ResolveWithScope(t, scopeID)
 	if err != nil {
 		return zero, fmt.Errorf("failed to resolve type %v in scope %s: %w", t, scopeID, err)
 	}
@@ -53,30 +52,85 @@ func ResolveScoped[T interface{}](scopeID string) (T, error) {
 	return castedInstance, nil
 }
 
-// GetProvider returns a provider for lazy resolution
-// @Param scopeID string - scope context identifier (empty string for default scope)
-func GetProvider[T any](scopeID string) container.Provider[T] {
-	return container.NewProvider[T](dependencyContainer, scopeID, "")
+// ResolveScopedCtxFrom resolves the instance within a scope of the given container, passing ctx
+// to constructors that take a context.Context. Resolution stops with ctx.Err() (wrapped with the
+// resolution path) once ctx is done, including while waiting for another goroutine's construction.
+func ResolveScopedCtxFrom[T interface{}](ctx context.Context, c *Container, scopeID string) (T, error) {
+	var zero T
+	t := reflect.TypeOf(&zero).Elem()
+	instance, err := c.dc.ResolveCtx(ctx, t, scopeID)
+	if err != nil {
+		return zero, fmt.Errorf("failed to resolve type %v: %w", t, err)
+	}
+
+	if instance == nil {
+		return zero, fmt.Errorf("resolved instance is nil for type %v", t)
+	}
+
+	castedInstance, ok := instance.(T)
+	if !ok {
+		return zero, fmt.Errorf("failed to cast resolved instance to type %v", t)
+	}
+
+	return castedInstance, nil
+}
+
+// ResolveCtxFrom resolves the instance from the given container with a context (see ResolveScopedCtxFrom)
+func ResolveCtxFrom[T interface{}](ctx context.Context, c *Container) (T, error) {
+	return ResolveScopedCtxFrom[T](ctx, c, "")
+}
+
+// CreateScope creates a new scope context and returns its ID
+func (c *Container) CreateScope() string {
+	return c.dc.CreateScope()
 }
 
-// GetProviderNamed returns a provider for lazy resolution of a named dependency
-func GetProviderNamed[T any](name string) container.Provider[T] {
-	return container.NewProvider[T](dependencyContainer, "", name)
+// DestroyScope cleans up a scope context and closes its instances
+// @Param scopeID string - scope context identifier to destroy
+func (c *Container) DestroyScope(scopeID string) error {
+	return c.dc.DestroyScope(scopeID)
+}
+
+// DestroyAllScopes cleans up all active scope contexts and closes their instances
+func (c *Container) DestroyAllScopes() error {
+	return c.dc.DestroyAllScopes()
+}
+
+// Resolve resolves the instance of the given type from the container
+func Resolve[T interface{}]() (T, error) {
+	return ResolveFrom[T](defaultContainer)
+}
+
+// ResolveScoped resolves the instance within a specific scope context
+// @Param scopeID string - scope context identifier
+func ResolveScoped[T interface{}](scopeID string) (T, error) {
+	return ResolveScopedFrom[T](defaultContainer, scopeID)
 }
 
 // CreateScope creates a new scope context and returns its ID
 func CreateScope() string {
-	return dependencyContainer.CreateScope()
+	return defaultContainer.CreateScope()
 }
 
-// DestroyScope cleans up a scope context and its instances
+// DestroyScope cleans up a scope context and closes its instances
 // @Param scopeID string - scope context identifier to destroy
-func DestroyScope(scopeID string) {
-	dependencyContainer.DestroyScope(scopeID)
+func DestroyScope(scopeID string) error {
+	return defaultContainer.DestroyScope(scopeID)
 }
 
-// DestroyAllScopes cleans up all active scope contexts
+// DestroyAllScopes cleans up all active scope contexts and closes their instances
 // Useful for application teardown or forced cleanup
-func DestroyAllScopes() {
-	dependencyContainer.DestroyAllScopes()
+func DestroyAllScopes() error {
+	return defaultContainer.DestroyAllScopes()
+}
+
+// ResolveCtx resolves the instance from the container with a context that is injected into
+// constructors taking a context.Context and that aborts the resolution when done
+func ResolveCtx[T interface{}](ctx context.Context) (T, error) {
+	return ResolveCtxFrom[T](ctx, defaultContainer)
+}
+
+// ResolveScopedCtx resolves the instance within a specific scope context with a context
+func ResolveScopedCtx[T interface{}](ctx context.Context, scopeID string) (T, error) {
+	return ResolveScopedCtxFrom[T](ctx, defaultContainer, scopeID)
 }
@@ -0,0 +1,14 @@
+package di
+
+import (
+	"github.com/binodta/depWeaver/internal/container"
+)
+
+// Scope defines the lifetime of a dependency
+type Scope = container.Scope
+
+const (
+	Singleton = container.Singleton // Created once and cached (default behavior)
+	Transient = container.Transient // Created every time it's requested
+	Scoped    = container.Scoped    // Created once per scope context
+)
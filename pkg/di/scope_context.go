@@ -0,0 +1,104 @@
+package di
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrNoScope is returned by ResolveFromContext when the context carries no scope
+var ErrNoScope = errors.New("no scope in context; create one with WithScope")
+
+// ScopeOption configures a scope created with WithScope
+type ScopeOption func(*scopeConfig)
+
+type scopeConfig struct {
+	destroyOnDone bool
+	onError       func(scopeID string, err error)
+}
+
+// DestroyOnDone destroys the scope as soon as the parent context is done,
+// so a forgotten release function does not leak the scope's instances
+func DestroyOnDone() ScopeOption {
+	return func(cfg *scopeConfig) {
+		cfg.destroyOnDone = true
+	}
+}
+
+// OnDestroyError receives the error returned while closing the scope's instances,
+// which the release function has no way to report (errors are dropped by default)
+func OnDestroyError(fn func(scopeID string, err error)) ScopeOption {
+	return func(cfg *scopeConfig) {
+		cfg.onError = fn
+	}
+}
+
+type scopeContextKey struct{}
+
+// contextScope is a scope owned by a context
+type contextScope struct {
+	c         *Container
+	id        string
+	destroyed atomic.Bool
+	once      sync.Once
+}
+
+// WithScope creates a scope bound to the returned context and a function that destroys it.
+// Resolve from the scope with ResolveFromContext. The release function is idempotent.
+func (c *Container) WithScope(ctx context.Context, opts ...ScopeOption) (context.Context, func()) {
+	var cfg scopeConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	scope := &contextScope{c: c, id: c.CreateScope()}
+	destroy := func() {
+		scope.once.Do(func() {
+			scope.destroyed.Store(true)
+			if err := c.DestroyScope(scope.id); err != nil && cfg.onError != nil {
+				cfg.onError(scope.id, err)
+			}
+		})
+	}
+	release := destroy
+	if cfg.destroyOnDone {
+		stop := context.AfterFunc(ctx, destroy)
+		release = func() {
+			stop()
+			destroy()
+		}
+	}
+
+	return context.WithValue(ctx, scopeContextKey{}, scope), release
+}
+
+// WithScope creates a scope of the default container bound to the returned context
+func WithScope(ctx context.Context, opts ...ScopeOption) (context.Context, func()) {
+	return defaultContainer.WithScope(ctx, opts...)
+}
+
+// ScopeFromContext returns the ID of the scope carried by ctx
+func ScopeFromContext(ctx context.Context) (string, bool) {
+	scope, ok := ctx.Value(scopeContextKey{}).(*contextScope)
+	if !ok {
+		return "", false
+	}
+	return scope.id, true
+}
+
+// ResolveFromContext resolves the instance within the scope carried by ctx, from the
+// container that created it. ctx is also passed to constructors (see ResolveCtx).
+func ResolveFromContext[T interface{}](ctx context.Context) (T, error) {
+	var zero T
+	scope, ok := ctx.Value(scopeContextKey{}).(*contextScope)
+	if !ok {
+		return zero, ErrNoScope
+	}
+	if scope.destroyed.Load() {
+		// Fail fast; builds racing with the destruction are refused by the container
+		return zero, &ScopeNotFoundError{ScopeID: scope.id, Type: reflect.TypeOf((*T)(nil)).Elem()}
+	}
+	return ResolveScopedCtxFrom[T](ctx, scope.c, scope.id)
+}
@@ -0,0 +1,69 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Supply registers an existing value as a singleton under its dynamic type.
+// Supplied values are injected as-is and are never closed by the container.
+// Like ProvideTo, it does not validate the graph.
+func (c *Container) Supply(value interface{}) error {
+	if value == nil {
+		return fmt.Errorf("cannot supply a nil value")
+	}
+	return c.dc.RegisterInstance(reflect.TypeOf(value), value)
+}
+
+// SupplyNamed registers an existing value as a named singleton under its dynamic type
+func (c *Container) SupplyNamed(name string, value interface{}) error {
+	if value == nil {
+		return fmt.Errorf("cannot supply a nil value")
+	}
+	return c.dc.RegisterNamedInstance(name, reflect.TypeOf(value), value)
+}
+
+// SupplyAsTo registers an existing value under its static type T in the given container.
+// Use it to supply interface values, e.g. SupplyAsTo[io.Writer](c, os.Stdout).
+func SupplyAsTo[T any](c *Container, value T) error {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	return c.dc.RegisterInstance(t, value)
+}
+
+// Supply registers an existing value as a singleton under its dynamic type
+func Supply(value interface{}) error {
+	return defaultContainer.Supply(value)
+}
+
+// SupplyNamed registers an existing value as a named singleton under its dynamic type
+func SupplyNamed(name string, value interface{}) error {
+	return defaultContainer.SupplyNamed(name, value)
+}
+
+// SupplyAs registers an existing value under its static type T (typically an interface)
+func SupplyAs[T any](value T) error {
+	return SupplyAsTo[T](defaultContainer, value)
+}
+
+// DeclareScopedTo registers T as a Scoped value of the given container that is supplied to each
+// scope with SupplyScopedTo instead of constructed (e.g. the current *http.Request).
+// It fails with a *DuplicateRegistrationError if T already has a constructor or value.
+func DeclareScopedTo[T any](c *Container) error {
+	return c.dc.DeclareScopedValue(reflect.TypeOf((*T)(nil)).Elem())
+}
+
+// SupplyScopedTo stores value as the instance of the declared scoped type T within a scope.
+// The value is not closed when the scope is destroyed.
+func SupplyScopedTo[T any](c *Container, scopeID string, value T) error {
+	return c.dc.SupplyScopedValue(scopeID, reflect.TypeOf((*T)(nil)).Elem(), value)
+}
+
+// DeclareScoped registers T as a Scoped value supplied to each scope with SupplyScoped
+func DeclareScoped[T any]() error {
+	return DeclareScopedTo[T](defaultContainer)
+}
+
+// SupplyScoped stores value as the instance of the declared scoped type T within a scope
+func SupplyScoped[T any](scopeID string, value T) error {
+	return SupplyScopedTo[T](defaultContainer, scopeID, value)
+}